@@ -0,0 +1,395 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Content types of the v2 API
+const (
+	contentTypeJSON      = "application/json"
+	contentTypeJSONMerge = "application/merge-patch+json"
+)
+
+// PageV2 used in the list endpoints of the v2 API
+type PageV2 struct {
+	PageIndex int64 `json:"pageIndex"`
+	PageSize  int64 `json:"pageSize"`
+	Total     int64 `json:"total"`
+}
+
+// UserV2 for unmarshalling users of api/v2/users-management/users
+type UserV2 struct {
+	ID          string   `json:"id"`
+	Login       string   `json:"login"`
+	Name        string   `json:"name"`
+	Email       string   `json:"email"`
+	Active      bool     `json:"active"`
+	Local       bool     `json:"local"`
+	Managed     bool     `json:"managed"`
+	ScmAccounts []string `json:"scmAccounts"`
+}
+
+// GetUsersV2 for unmarshalling response body of api/v2/users-management/users
+type GetUsersV2 struct {
+	Page  PageV2   `json:"page"`
+	Users []UserV2 `json:"users"`
+}
+
+// GroupV2 for unmarshalling groups of api/v2/authorizations/groups
+type GroupV2 struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Managed     bool   `json:"managed"`
+	Default     bool   `json:"default"`
+}
+
+// GetGroupsV2 for unmarshalling response body of api/v2/authorizations/groups
+type GetGroupsV2 struct {
+	Page   PageV2    `json:"page"`
+	Groups []GroupV2 `json:"groups"`
+}
+
+// GroupMembershipV2 for unmarshalling memberships of api/v2/authorizations/group-memberships
+type GroupMembershipV2 struct {
+	ID      string `json:"id"`
+	GroupID string `json:"groupId"`
+	UserID  string `json:"userId"`
+}
+
+// GetGroupMembershipsV2 for unmarshalling response body of api/v2/authorizations/group-memberships
+type GetGroupMembershipsV2 struct {
+	Page             PageV2              `json:"page"`
+	GroupMemberships []GroupMembershipV2 `json:"groupMemberships"`
+}
+
+// usersV2ApiSupported reports whether api/v2/users-management replaces api/users, which is the case since SonarQube 10.4
+func usersV2ApiSupported(m interface{}) bool {
+	minimumVersion, _ := version.NewVersion("10.4")
+	return m.(*ProviderConfiguration).sonarQubeVersion.GreaterThanOrEqual(minimumVersion)
+}
+
+// groupsV2ApiSupported reports whether api/v2/authorizations replaces api/user_groups, which is the case since SonarQube 10.5
+func groupsV2ApiSupported(m interface{}) bool {
+	minimumVersion, _ := version.NewVersion("10.5")
+	return m.(*ProviderConfiguration).sonarQubeVersion.GreaterThanOrEqual(minimumVersion)
+}
+
+// dopTranslationV2ApiSupported reports whether GitHub and GitLab authentication are configured with api/v2/dop-translation,
+// which is the case since SonarQube 10.5
+func dopTranslationV2ApiSupported(m interface{}) bool {
+	minimumVersion, _ := version.NewVersion("10.5")
+	return m.(*ProviderConfiguration).sonarQubeVersion.GreaterThanOrEqual(minimumVersion)
+}
+
+// sonarqubeV2URL returns the url of a v2 API endpoint
+func sonarqubeV2URL(m interface{}, path string, query url.Values) string {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/" + path
+	if query != nil {
+		sonarQubeURL.RawQuery = query.Encode()
+	}
+	return sonarQubeURL.String()
+}
+
+// listUsersV2 returns all users matching the query, reading every page
+func listUsersV2(query url.Values, m interface{}) ([]UserV2, error) {
+	users := make([]UserV2, 0)
+	page := int64(1)
+	for {
+		query.Set("pageIndex", strconv.FormatInt(page, 10))
+		query.Set("pageSize", "100")
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarqubeV2URL(m, "users-management/users", query),
+			http.StatusOK,
+			"listUsersV2",
+		)
+		if err != nil {
+			return nil, err
+		}
+		// Decode response into struct
+		usersResponse := GetUsersV2{}
+		err = json.NewDecoder(resp.Body).Decode(&usersResponse)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("listUsersV2: Failed to decode json into struct: %+v", err)
+		}
+		users = append(users, usersResponse.Users...)
+
+		// Stop once the last page has been read
+		if len(usersResponse.Users) == 0 || usersResponse.Page.PageIndex*usersResponse.Page.PageSize >= usersResponse.Page.Total {
+			break
+		}
+		page++
+	}
+	return users, nil
+}
+
+// findUserV2 returns the active or deactivated user with the given login, or nil if there is none
+func findUserV2(login string, active bool, m interface{}) (*UserV2, error) {
+	users, err := listUsersV2(url.Values{
+		"q":      []string{login},
+		"active": []string{strconv.FormatBool(active)},
+	}, m)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		if users[i].Login == login {
+			return &users[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// listGroupsV2 returns all groups matching the query, reading every page
+func listGroupsV2(query url.Values, m interface{}) ([]GroupV2, error) {
+	groups := make([]GroupV2, 0)
+	page := int64(1)
+	for {
+		query.Set("pageIndex", strconv.FormatInt(page, 10))
+		query.Set("pageSize", "100")
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarqubeV2URL(m, "authorizations/groups", query),
+			http.StatusOK,
+			"listGroupsV2",
+		)
+		if err != nil {
+			return nil, err
+		}
+		// Decode response into struct
+		groupsResponse := GetGroupsV2{}
+		err = json.NewDecoder(resp.Body).Decode(&groupsResponse)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("listGroupsV2: Failed to decode json into struct: %+v", err)
+		}
+		groups = append(groups, groupsResponse.Groups...)
+
+		// Stop once the last page has been read
+		if len(groupsResponse.Groups) == 0 || groupsResponse.Page.PageIndex*groupsResponse.Page.PageSize >= groupsResponse.Page.Total {
+			break
+		}
+		page++
+	}
+	return groups, nil
+}
+
+// findGroupV2 returns the group with the given id, or with the given name when no id is known. It returns nil if there is
+// no such group. Groups are not searched by name when an id is known, so groups renamed outside of terraform are still found.
+func findGroupV2(id string, name string, m interface{}) (*GroupV2, error) {
+	if id != "" {
+		return getGroupV2(id, m)
+	}
+
+	groups, err := listGroupsV2(url.Values{
+		"q": []string{name},
+	}, m)
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		if groups[i].Name == name {
+			return &groups[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// getGroupV2 returns the group with the given id, or nil if there is none
+func getGroupV2(id string, m interface{}) (*GroupV2, error) {
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarqubeV2URL(m, "authorizations/groups/"+url.PathEscape(id), nil),
+		http.StatusOK,
+		"getGroupV2",
+	)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	group := GroupV2{}
+	err = json.NewDecoder(resp.Body).Decode(&group)
+	if err != nil {
+		return nil, fmt.Errorf("getGroupV2: Failed to decode json into struct: %+v", err)
+	}
+	return &group, nil
+}
+
+// findGroupMembershipV2 returns the membership of the user in the group, or nil if the user is not a member
+func findGroupMembershipV2(groupID string, userID string, m interface{}) (*GroupMembershipV2, error) {
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarqubeV2URL(m, "authorizations/group-memberships", url.Values{
+			"groupId": []string{groupID},
+			"userId":  []string{userID},
+		}),
+		http.StatusOK,
+		"findGroupMembershipV2",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	membershipsResponse := GetGroupMembershipsV2{}
+	err = json.NewDecoder(resp.Body).Decode(&membershipsResponse)
+	if err != nil {
+		return nil, fmt.Errorf("findGroupMembershipV2: Failed to decode json into struct: %+v", err)
+	}
+	if len(membershipsResponse.GroupMemberships) == 0 {
+		return nil, nil
+	}
+	return &membershipsResponse.GroupMemberships[0], nil
+}
+
+// countGroupMembershipsV2 returns the number of members of the group
+func countGroupMembershipsV2(groupID string, m interface{}) (int64, error) {
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarqubeV2URL(m, "authorizations/group-memberships", url.Values{
+			"groupId":  []string{groupID},
+			"pageSize": []string{"1"},
+		}),
+		http.StatusOK,
+		"countGroupMembershipsV2",
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	membershipsResponse := GetGroupMembershipsV2{}
+	err = json.NewDecoder(resp.Body).Decode(&membershipsResponse)
+	if err != nil {
+		return 0, fmt.Errorf("countGroupMembershipsV2: Failed to decode json into struct: %+v", err)
+	}
+	return membershipsResponse.Page.Total, nil
+}
+
+// readGroupMembersV2 returns the logins of all members of the group, or nil if the group does not exist
+func readGroupMembersV2(groupName string, m interface{}) ([]string, error) {
+	group, err := findGroupV2("", groupName, m)
+	if err != nil || group == nil {
+		return nil, err
+	}
+	users, err := listUsersV2(url.Values{
+		"groupId": []string{group.ID},
+	}, m)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]string, 0, len(users))
+	for _, user := range users {
+		members = append(members, user.Login)
+	}
+	return members, nil
+}
+
+// groupMemberExistsV2 reports whether the user is a member of the group. A missing group or user is no membership.
+func groupMemberExistsV2(groupName string, login string, m interface{}) (bool, error) {
+	group, err := findGroupV2("", groupName, m)
+	if err != nil || group == nil {
+		return false, err
+	}
+	user, err := findUserV2(login, true, m)
+	if err != nil || user == nil {
+		return false, err
+	}
+	membership, err := findGroupMembershipV2(group.ID, user.ID, m)
+	if err != nil {
+		return false, err
+	}
+	return membership != nil, nil
+}
+
+// resolveGroupMemberV2 returns the v2 ids of the group and the user
+func resolveGroupMemberV2(groupName string, login string, m interface{}) (string, string, error) {
+	group, err := findGroupV2("", groupName, m)
+	if err != nil {
+		return "", "", err
+	}
+	if group == nil {
+		return "", "", fmt.Errorf("group '%s' does not exist", groupName)
+	}
+	user, err := findUserV2(login, true, m)
+	if err != nil {
+		return "", "", err
+	}
+	if user == nil {
+		return "", "", fmt.Errorf("user '%s' does not exist", login)
+	}
+	return group.ID, user.ID, nil
+}
+
+// setGroupMemberV2 adds the user to or removes the user from the group
+func setGroupMemberV2(groupName string, login string, add bool, m interface{}) error {
+	groupID, userID, err := resolveGroupMemberV2(groupName, login, m)
+	if err != nil {
+		return err
+	}
+
+	if add {
+		resp, err := httpRequestJSONHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"POST",
+			sonarqubeV2URL(m, "authorizations/group-memberships", nil),
+			map[string]string{
+				"groupId": groupID,
+				"userId":  userID,
+			},
+			contentTypeJSON,
+			http.StatusOK,
+			"setGroupMemberV2",
+		)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		return nil
+	}
+
+	membership, err := findGroupMembershipV2(groupID, userID, m)
+	if err != nil {
+		return err
+	}
+	if membership == nil {
+		// The user is not a member anymore
+		return nil
+	}
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"DELETE",
+		sonarqubeV2URL(m, "authorizations/group-memberships/"+url.PathEscape(membership.ID), nil),
+		http.StatusNoContent,
+		"setGroupMemberV2",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
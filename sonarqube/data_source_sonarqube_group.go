@@ -1,6 +1,13 @@
 package sonarqube
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -12,15 +19,195 @@ func dataSourceSonarqubeGroup() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"include_members": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Also return the logins of all members of the group",
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"members_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of members of the group",
+			},
+			"default": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether this is the default group all users are added to",
+			},
+			"managed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the group is managed by an external provisioning system",
+			},
+			"members": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The logins of the members of the group. Only set when include_members is true",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"permissions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The global permissions of the group",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
 
 func dataSourceSonarqubeGroupRead(d *schema.ResourceData, m interface{}) error {
-	d.SetId(d.Get("name").(string))
-	return resourceSonarqubeGroupRead(d, m)
+	groupName := d.Get("name").(string)
+
+	group, err := readDataSourceGroup(groupName, m)
+	if err != nil {
+		return fmt.Errorf("error reading Sonarqube group: %+v", err)
+	}
+	if group == nil {
+		return fmt.Errorf("dataSourceSonarqubeGroupRead: Failed to find group: %+v", groupName)
+	}
+
+	permissions, err := readGroupGlobalPermissionsFromApi(groupName, m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeGroupRead: Failed to read permissions of group '%s': %+v", groupName, err)
+	}
+
+	members := make([]string, 0)
+	if d.Get("include_members").(bool) {
+		members, err = readGroupMembersFromApi(groupName, m)
+		if err != nil {
+			return fmt.Errorf("dataSourceSonarqubeGroupRead: Failed to read members of group '%s': %+v", groupName, err)
+		}
+	}
+
+	// No ID in the group search response from sonarqube 10.0+
+	if group.ID != "" {
+		d.SetId(group.ID)
+	} else {
+		d.SetId(group.Name)
+	}
+	d.Set("name", group.Name)
+	d.Set("description", group.Description)
+	d.Set("members_count", group.MembersCount)
+	d.Set("default", group.IsDefault)
+	d.Set("managed", group.Managed)
+	d.Set("members", members)
+	d.Set("permissions", permissions)
+	return nil
+}
+
+// readDataSourceGroup returns the group with the given name, or nil if there is none
+func readDataSourceGroup(groupName string, m interface{}) (*Group, error) {
+	if groupsV2ApiSupported(m) {
+		groupV2, err := findGroupV2("", groupName, m)
+		if err != nil || groupV2 == nil {
+			return nil, err
+		}
+		membersCount, err := countGroupMembershipsV2(groupV2.ID, m)
+		if err != nil {
+			return nil, err
+		}
+		return &Group{
+			ID:           groupV2.ID,
+			Name:         groupV2.Name,
+			Description:  groupV2.Description,
+			MembersCount: int(membersCount),
+			IsDefault:    groupV2.Default,
+			Managed:      groupV2.Managed,
+		}, nil
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"ps": []string{"500"},
+		"q":  []string{groupName},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readDataSourceGroup",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	groupReadResponse := GetGroup{}
+	err = json.NewDecoder(resp.Body).Decode(&groupReadResponse)
+	if err != nil {
+		return nil, fmt.Errorf("readDataSourceGroup: Failed to decode json into struct: %+v", err)
+	}
+
+	for i, value := range groupReadResponse.Groups {
+		if value.Name == groupName {
+			return &groupReadResponse.Groups[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// readGroupGlobalPermissionsFromApi returns the global permissions of the group
+func readGroupGlobalPermissionsFromApi(groupName string, m interface{}) ([]string, error) {
+	page := int64(1)
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/groups"
+		rawQuery := url.Values{
+			"p":  []string{strconv.FormatInt(page, 10)},
+			"ps": []string{"100"},
+		}
+		// The search query must be at least 3 characters long
+		if len(groupName) >= 3 {
+			rawQuery.Add("q", groupName)
+		}
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"readGroupGlobalPermissionsFromApi",
+		)
+		if err != nil {
+			return nil, err
+		}
+		// Decode response into struct
+		permissionsResponse := GetGroup{}
+		err = json.NewDecoder(resp.Body).Decode(&permissionsResponse)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("readGroupGlobalPermissionsFromApi: Failed to decode json into struct: %+v", err)
+		}
+
+		for _, value := range permissionsResponse.Groups {
+			if value.Name == groupName {
+				if value.Permissions == nil {
+					return []string{}, nil
+				}
+				return value.Permissions, nil
+			}
+		}
+
+		// Stop once the last page has been read
+		if len(permissionsResponse.Groups) == 0 || permissionsResponse.Paging.PageIndex*permissionsResponse.Paging.PageSize >= permissionsResponse.Paging.Total {
+			break
+		}
+		page++
+	}
+
+	return []string{}, nil
 }
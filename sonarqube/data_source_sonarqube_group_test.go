@@ -37,3 +37,55 @@ func TestAccSonarqubeGroupDataSource(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeGroupDataSourceMembersConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name = "%[2]s-user"
+			name       = "Test User"
+			password   = "secret-sauce37!"
+		}
+
+		resource "sonarqube_group" "%[1]s" {
+			name = "%[2]s"
+		}
+
+		resource "sonarqube_group_members" "%[1]s" {
+			name    = sonarqube_group.%[1]s.name
+			members = [sonarqube_user.%[1]s.login_name]
+		}
+
+		resource "sonarqube_permissions" "%[1]s" {
+			group_name  = sonarqube_group.%[1]s.name
+			permissions = ["scan"]
+		}
+
+		data "sonarqube_group" "%[1]s" {
+			name            = sonarqube_group_members.%[1]s.name
+			include_members = true
+
+			depends_on = [sonarqube_permissions.%[1]s]
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeGroupDataSourceMembers(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_group." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGroupDataSourceMembersConfig(rnd, "testAccSonarqubeGroupDataSourceMembers"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "members_count", "1"),
+					resource.TestCheckResourceAttr(name, "members.0", "testAccSonarqubeGroupDataSourceMembers-user"),
+					resource.TestCheckResourceAttr(name, "default", "false"),
+					resource.TestCheckResourceAttr(name, "permissions.#", "1"),
+					resource.TestCheckResourceAttr(name, "permissions.0", "scan"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,128 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GetLanguages for unmarshalling response body of api/languages/list
+type GetLanguages struct {
+	Languages []Language `json:"languages"`
+}
+
+// Language used in GetLanguages
+type Language struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+func dataSourceSonarqubeLanguages() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeLanguagesRead,
+		Schema: map[string]*schema.Schema{
+			"languages": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The languages supported by the server",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"keys": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The keys of the languages supported by the server",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeLanguagesRead(d *schema.ResourceData, m interface{}) error {
+	languages, err := getLanguagesFromApi(m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeLanguagesRead: %+v", err)
+	}
+
+	flatLanguages := make([]interface{}, len(languages))
+	keys := make([]string, len(languages))
+	for i, language := range languages {
+		flatLanguages[i] = map[string]interface{}{
+			"key":  language.Key,
+			"name": language.Name,
+		}
+		keys[i] = language.Key
+	}
+
+	d.SetId("languages")
+	d.Set("languages", flatLanguages)
+	d.Set("keys", keys)
+	return nil
+}
+
+// getLanguagesFromApi returns all languages supported by the server
+func getLanguagesFromApi(m interface{}) ([]Language, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/languages/list"
+	// A page size of 0 returns all languages
+	sonarQubeURL.RawQuery = "ps=0"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"getLanguagesFromApi",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	languagesResponse := GetLanguages{}
+	err = json.NewDecoder(resp.Body).Decode(&languagesResponse)
+	if err != nil {
+		return nil, fmt.Errorf("getLanguagesFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return languagesResponse.Languages, nil
+}
+
+// validateLanguage checks at plan time that the configured language is supported by the server
+func validateLanguage(d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("language") || (d.Id() != "" && !d.HasChange("language")) {
+		return nil
+	}
+
+	languages, err := getLanguagesFromApi(m)
+	if err != nil {
+		return fmt.Errorf("validateLanguage: Failed to read the languages of the server: %+v", err)
+	}
+
+	language := d.Get("language").(string)
+	keys := make([]string, len(languages))
+	for i, value := range languages {
+		if value.Key == language {
+			return nil
+		}
+		keys[i] = value.Key
+	}
+
+	return fmt.Errorf("validateLanguage: Language '%s' is not supported by the server. Supported languages are: %s", language, strings.Join(keys, ", "))
+}
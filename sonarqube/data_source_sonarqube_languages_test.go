@@ -0,0 +1,42 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeLanguagesDataSourceConfig(rnd string) string {
+	return fmt.Sprintf(`
+		data "sonarqube_languages" "%[1]s" {}
+
+		data "sonarqube_rule_repositories" "%[1]s" {
+			language = "java"
+		}`, rnd)
+}
+
+func TestAccSonarqubeLanguagesDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeLanguagesDataSourceConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.sonarqube_languages."+rnd, "keys.*", "java"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.sonarqube_languages."+rnd, "languages.*", map[string]string{
+						"key":  "java",
+						"name": "Java",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.sonarqube_rule_repositories."+rnd, "repositories.*", map[string]string{
+						"key":      "java",
+						"language": "java",
+					}),
+				),
+			},
+		},
+	})
+}
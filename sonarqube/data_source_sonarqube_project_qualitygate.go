@@ -0,0 +1,68 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSonarqubeProjectQualityGate() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeProjectQualityGateRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the project",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the quality gate used by the project",
+			},
+			"is_default": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the project uses the default quality gate",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeProjectQualityGateRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/get_by_project"
+	sonarQubeURL.RawQuery = url.Values{
+		"project": []string{project},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"dataSourceSonarqubeProjectQualityGateRead",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	qualityGateAssociationReadResponse := GetQualityGateAssociation{}
+	err = json.NewDecoder(resp.Body).Decode(&qualityGateAssociationReadResponse)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeProjectQualityGateRead: Failed to decode json into struct: %+v", err)
+	}
+
+	d.SetId(project)
+	d.Set("name", qualityGateAssociationReadResponse.QualityGate.Name)
+	d.Set("is_default", qualityGateAssociationReadResponse.QualityGate.Default)
+	return nil
+}
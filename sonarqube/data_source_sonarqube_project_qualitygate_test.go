@@ -0,0 +1,67 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeProjectQualityGateDataSourceConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_project" "%[1]s_default" {
+			name       = "%[2]sDefault"
+			project    = "%[2]sDefault"
+			visibility = "public"
+		}
+
+		resource "sonarqube_qualitygate" "%[1]s" {
+			name = "%[2]s"
+
+			condition {
+				metric    = "new_coverage"
+				op        = "LT"
+				threshold = "30"
+			}
+		}
+
+		resource "sonarqube_qualitygate_project_association" "%[1]s" {
+			gatename   = sonarqube_qualitygate.%[1]s.name
+			projectkey = sonarqube_project.%[1]s.project
+		}
+
+		data "sonarqube_project_qualitygate" "%[1]s" {
+			project = sonarqube_qualitygate_project_association.%[1]s.projectkey
+		}
+
+		data "sonarqube_project_qualitygate" "%[1]s_default" {
+			project = sonarqube_project.%[1]s_default.project
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeProjectQualityGateDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_project_qualitygate." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectQualityGateDataSourceConfig(rnd, "testAccSonarqubeProjectQualityGateDataSource"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeProjectQualityGateDataSource"),
+					resource.TestCheckResourceAttr(name, "is_default", "false"),
+					resource.TestCheckResourceAttrSet(name+"_default", "name"),
+					resource.TestCheckResourceAttr(name+"_default", "is_default", "true"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,102 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSonarqubeProjectQualityProfiles() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeProjectQualityProfilesRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the project",
+			},
+			"quality_profiles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The quality profiles used by the project, one per language",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"language": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_default": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_built_in": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"parent": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeProjectQualityProfilesRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"project": []string{project},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"dataSourceSonarqubeProjectQualityProfilesRead",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	getQualityProfileResponse := GetQualityProfileList{}
+	err = json.NewDecoder(resp.Body).Decode(&getQualityProfileResponse)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeProjectQualityProfilesRead: Failed to decode json into struct: %+v", err)
+	}
+
+	qualityProfiles := make([]interface{}, len(getQualityProfileResponse.Profiles))
+	for i, value := range getQualityProfileResponse.Profiles {
+		qualityProfiles[i] = map[string]interface{}{
+			"key":         value.Key,
+			"name":        value.Name,
+			"language":    value.Language,
+			"is_default":  value.IsDefault,
+			"is_built_in": value.IsBuiltIn,
+			"parent":      value.ParentName,
+		}
+	}
+
+	d.SetId(project)
+	d.Set("quality_profiles", qualityProfiles)
+	return nil
+}
@@ -0,0 +1,58 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeProjectQualityProfilesDataSourceConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "js"
+		}
+
+		resource "sonarqube_qualityprofile_project_association" "%[1]s" {
+			quality_profile = sonarqube_qualityprofile.%[1]s.name
+			project         = sonarqube_project.%[1]s.project
+			language        = "js"
+		}
+
+		data "sonarqube_project_qualityprofiles" "%[1]s" {
+			project = sonarqube_qualityprofile_project_association.%[1]s.project
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeProjectQualityProfilesDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_project_qualityprofiles." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectQualityProfilesDataSourceConfig(rnd, "testAccSonarqubeProjectQualityProfilesDataSource"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(name, "quality_profiles.*", map[string]string{
+						"name":       "testAccSonarqubeProjectQualityProfilesDataSource",
+						"language":   "js",
+						"is_default": "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "quality_profiles.*", map[string]string{
+						"language":   "java",
+						"is_default": "true",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,204 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// GetQualityProfileChangelog for unmarshalling response body of api/qualityprofiles/changelog
+type GetQualityProfileChangelog struct {
+	Total  int                               `json:"total"`
+	P      int                               `json:"p"`
+	PS     int                               `json:"ps"`
+	Events []GetQualityProfileChangelogEvent `json:"events"`
+}
+
+// GetQualityProfileChangelogEvent used in GetQualityProfileChangelog
+type GetQualityProfileChangelogEvent struct {
+	Date        string            `json:"date"`
+	AuthorLogin string            `json:"authorLogin"`
+	AuthorName  string            `json:"authorName"`
+	Action      string            `json:"action"`
+	RuleKey     string            `json:"ruleKey"`
+	RuleName    string            `json:"ruleName"`
+	Params      map[string]string `json:"params"`
+}
+
+func dataSourceSonarqubeQualityProfileChangelog() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeQualityProfileChangelogRead,
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"key", "name"},
+				Description:  "The key of the quality profile",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"language"},
+				Description:  "The name of the quality profile",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The language of the quality profile. Required when the profile is looked up by name",
+			},
+			"since": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Start date for the changelog, either a date (yyyy-MM-dd) or a datetime (yyyy-MM-ddTHH:mm:ssZ)",
+			},
+			"to": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "End date for the changelog, either a date (yyyy-MM-dd) or a datetime (yyyy-MM-ddTHH:mm:ssZ)",
+			},
+			"max_events": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     500,
+				Description: "The maximum number of events to return, starting with the most recent one",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.IntAtLeast(1),
+				),
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of events in the date range",
+			},
+			"events": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The changelog events, most recent first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"author_login": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"author_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rule_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rule_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"params": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeQualityProfileChangelogRead(d *schema.ResourceData, m interface{}) error {
+	qualityProfile, err := findQualityProfile(d.Get("key").(string), d.Get("name").(string), d.Get("language").(string), m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileChangelogRead: %+v", err)
+	}
+	if qualityProfile == nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileChangelogRead: Failed to find quality profile")
+	}
+
+	maxEvents := d.Get("max_events").(int)
+	events := make([]interface{}, 0)
+	total := 0
+	page := int64(1)
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/changelog"
+		rawQuery := url.Values{
+			"qualityProfile": []string{qualityProfile.Name},
+			"language":       []string{qualityProfile.Language},
+			"p":              []string{strconv.FormatInt(page, 10)},
+			"ps":             []string{"100"},
+		}
+		if since, ok := d.GetOk("since"); ok {
+			rawQuery.Add("since", since.(string))
+		}
+		if to, ok := d.GetOk("to"); ok {
+			rawQuery.Add("to", to.(string))
+		}
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"dataSourceSonarqubeQualityProfileChangelogRead",
+		)
+		if err != nil {
+			return err
+		}
+		// Decode response into struct
+		changelog := GetQualityProfileChangelog{}
+		err = json.NewDecoder(resp.Body).Decode(&changelog)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("dataSourceSonarqubeQualityProfileChangelogRead: Failed to decode json into struct: %+v", err)
+		}
+
+		total = changelog.Total
+		for _, event := range changelog.Events {
+			if len(events) >= maxEvents {
+				break
+			}
+			events = append(events, map[string]interface{}{
+				"date":         event.Date,
+				"author_login": event.AuthorLogin,
+				"author_name":  event.AuthorName,
+				"action":       event.Action,
+				"rule_key":     event.RuleKey,
+				"rule_name":    event.RuleName,
+				"params":       event.Params,
+			})
+		}
+
+		// Stop once the last page has been read or enough events were collected
+		if len(events) >= maxEvents || len(changelog.Events) == 0 || changelog.P*changelog.PS >= changelog.Total {
+			break
+		}
+		page++
+	}
+
+	d.SetId(fmt.Sprintf("%v/%v/%v", qualityProfile.Key, d.Get("since").(string), d.Get("to").(string)))
+	d.Set("key", qualityProfile.Key)
+	d.Set("name", qualityProfile.Name)
+	d.Set("language", qualityProfile.Language)
+	d.Set("total", total)
+	d.Set("events", events)
+	return nil
+}
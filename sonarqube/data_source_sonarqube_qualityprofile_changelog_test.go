@@ -0,0 +1,53 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeQualityProfileChangelogDataSourceConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "java"
+		}
+
+		resource "sonarqube_qualityprofile_rules" "%[1]s" {
+			key = sonarqube_qualityprofile.%[1]s.key
+
+			rule {
+				key      = "java:S1135"
+				severity = "INFO"
+			}
+		}
+
+		data "sonarqube_qualityprofile_changelog" "%[1]s" {
+			name     = sonarqube_qualityprofile.%[1]s.name
+			language = "java"
+
+			depends_on = [sonarqube_qualityprofile_rules.%[1]s]
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeQualityProfileChangelogDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_qualityprofile_changelog." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileChangelogDataSourceConfig(rnd, "testAccSonarqubeQualityProfileChangelogDataSource"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "key"),
+					resource.TestCheckResourceAttr(name, "total", "1"),
+					resource.TestCheckResourceAttr(name, "events.0.action", "ACTIVATED"),
+					resource.TestCheckResourceAttr(name, "events.0.rule_key", "java:S1135"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,268 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// CompareQualityProfiles for unmarshalling response body of api/qualityprofiles/compare
+type CompareQualityProfiles struct {
+	Left     QualityProfile               `json:"left"`
+	Right    QualityProfile               `json:"right"`
+	InLeft   []CompareQualityProfilesRule `json:"inLeft"`
+	InRight  []CompareQualityProfilesRule `json:"inRight"`
+	Modified []CompareQualityProfilesRule `json:"modified"`
+}
+
+// CompareQualityProfilesRule used in CompareQualityProfiles
+type CompareQualityProfilesRule struct {
+	Key      string                           `json:"key"`
+	Name     string                           `json:"name"`
+	Severity string                           `json:"severity"`
+	Params   map[string]string                `json:"params"`
+	Left     CompareQualityProfilesActivation `json:"left"`
+	Right    CompareQualityProfilesActivation `json:"right"`
+}
+
+// CompareQualityProfilesActivation used in CompareQualityProfilesRule
+type CompareQualityProfilesActivation struct {
+	Severity string            `json:"severity"`
+	Params   map[string]string `json:"params"`
+}
+
+func dataSourceSonarqubeQualityProfileComparison() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeQualityProfileComparisonRead,
+		Schema: map[string]*schema.Schema{
+			"left_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"left_key", "left_name"},
+				Description:  "The key of the left quality profile",
+			},
+			"left_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"language"},
+				Description:  "The name of the left quality profile",
+			},
+			"right_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"right_key", "right_name"},
+				Description:  "The key of the right quality profile",
+			},
+			"right_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"language"},
+				Description:  "The name of the right quality profile",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The language of the quality profiles. Required when a profile is looked up by name",
+			},
+			"in_left": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rules that are only active in the left quality profile",
+				Elem:        dataSourceSonarqubeQualityProfileComparisonRuleSchema(),
+			},
+			"in_right": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rules that are only active in the right quality profile",
+				Elem:        dataSourceSonarqubeQualityProfileComparisonRuleSchema(),
+			},
+			"modified": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rules that are active in both quality profiles with a different severity or different parameters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"left_severity": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"left_params": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"right_severity": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"right_params": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeQualityProfileComparisonRuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"severity": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"params": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeQualityProfileComparisonRead(d *schema.ResourceData, m interface{}) error {
+	language := d.Get("language").(string)
+
+	left, err := findQualityProfile(d.Get("left_key").(string), d.Get("left_name").(string), language, m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileComparisonRead: %+v", err)
+	}
+	if left == nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileComparisonRead: Failed to find left quality profile")
+	}
+	right, err := findQualityProfile(d.Get("right_key").(string), d.Get("right_name").(string), language, m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileComparisonRead: %+v", err)
+	}
+	if right == nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileComparisonRead: Failed to find right quality profile")
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/compare"
+	sonarQubeURL.RawQuery = url.Values{
+		"leftKey":  []string{left.Key},
+		"rightKey": []string{right.Key},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"dataSourceSonarqubeQualityProfileComparisonRead",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	comparison := CompareQualityProfiles{}
+	err = json.NewDecoder(resp.Body).Decode(&comparison)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileComparisonRead: Failed to decode json into struct: %+v", err)
+	}
+
+	inLeft, err := flattenQualityProfileComparisonRules(comparison.InLeft, left.Key, m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileComparisonRead: %+v", err)
+	}
+	inRight, err := flattenQualityProfileComparisonRules(comparison.InRight, right.Key, m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileComparisonRead: %+v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%v/%v", left.Key, right.Key))
+	d.Set("left_key", left.Key)
+	d.Set("left_name", left.Name)
+	d.Set("right_key", right.Key)
+	d.Set("right_name", right.Name)
+	d.Set("language", left.Language)
+	d.Set("in_left", inLeft)
+	d.Set("in_right", inRight)
+	d.Set("modified", flattenQualityProfileComparisonModifiedRules(comparison.Modified))
+	return nil
+}
+
+// flattenQualityProfileComparisonRules returns the rules that are only active in one of the quality profiles. Their params
+// are taken from the active rules of that quality profile when api/qualityprofiles/compare does not return them.
+func flattenQualityProfileComparisonRules(input []CompareQualityProfilesRule, profileKey string, m interface{}) ([]interface{}, error) {
+	var activeRules []QualityProfileActiveRule
+	for _, rule := range input {
+		if rule.Params == nil {
+			var err error
+			if activeRules, err = readQualityProfileActiveRulesFromApi(profileKey, m); err != nil {
+				return nil, fmt.Errorf("Failed to read active rules of quality profile '%s': %+v", profileKey, err)
+			}
+			break
+		}
+	}
+
+	flatRules := make([]interface{}, len(input))
+	for i, rule := range input {
+		params := rule.Params
+		if params == nil {
+			params = make(map[string]string)
+			if activeRule := findQualityProfileActiveRule(activeRules, rule.Key); activeRule != nil {
+				params = activeRule.Params
+			}
+		}
+		flatRules[i] = map[string]interface{}{
+			"key":      rule.Key,
+			"name":     rule.Name,
+			"severity": rule.Severity,
+			"params":   params,
+		}
+	}
+	return flatRules, nil
+}
+
+func flattenQualityProfileComparisonModifiedRules(input []CompareQualityProfilesRule) []interface{} {
+	flatRules := make([]interface{}, len(input))
+	for i, rule := range input {
+		flatRules[i] = map[string]interface{}{
+			"key":            rule.Key,
+			"name":           rule.Name,
+			"left_severity":  rule.Left.Severity,
+			"left_params":    rule.Left.Params,
+			"right_severity": rule.Right.Severity,
+			"right_params":   rule.Right.Params,
+		}
+	}
+	return flatRules
+}
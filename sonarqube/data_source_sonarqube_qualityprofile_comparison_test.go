@@ -0,0 +1,57 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeQualityProfileComparisonDataSourceConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "java"
+		}
+
+		resource "sonarqube_qualityprofile_rules" "%[1]s" {
+			key = sonarqube_qualityprofile.%[1]s.key
+
+			rule {
+				key      = "java:S107"
+				severity = "MAJOR"
+				params = {
+					max = "9"
+				}
+			}
+		}
+
+		data "sonarqube_qualityprofile_comparison" "%[1]s" {
+			left_key   = sonarqube_qualityprofile_rules.%[1]s.key
+			right_name = "Sonar way"
+			language   = "java"
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeQualityProfileComparisonDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_qualityprofile_comparison." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileComparisonDataSourceConfig(rnd, "testAccSonarqubeQualityProfileComparisonDataSource"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "left_name", "testAccSonarqubeQualityProfileComparisonDataSource"),
+					resource.TestCheckResourceAttrSet(name, "right_key"),
+					resource.TestCheckResourceAttr(name, "in_left.#", "1"),
+					resource.TestCheckResourceAttr(name, "in_left.0.key", "java:S107"),
+					resource.TestCheckResourceAttr(name, "in_left.0.params.max", "9"),
+					resource.TestCheckResourceAttrSet(name, "in_right.#"),
+				),
+			},
+		},
+	})
+}
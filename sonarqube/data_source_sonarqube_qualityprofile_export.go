@@ -0,0 +1,94 @@
+package sonarqube
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSonarqubeQualityProfileExport() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeQualityProfileExportRead,
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"key", "name"},
+				Description:  "The key of the quality profile",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"language"},
+				Description:  "The name of the quality profile",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The language of the quality profile. Required when the profile is looked up by name",
+			},
+			"exporter_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The key of the exporter. When unset the profile is exported in the SonarQube backup format",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The exported quality profile",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeQualityProfileExportRead(d *schema.ResourceData, m interface{}) error {
+	qualityProfile, err := findQualityProfile(d.Get("key").(string), d.Get("name").(string), d.Get("language").(string), m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileExportRead: %+v", err)
+	}
+	if qualityProfile == nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileExportRead: Failed to find quality profile")
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/export"
+	rawQuery := url.Values{
+		"qualityProfile": []string{qualityProfile.Name},
+		"language":       []string{qualityProfile.Language},
+	}
+	if exporterKey, ok := d.GetOk("exporter_key"); ok {
+		rawQuery.Add("exporterKey", exporterKey.(string))
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"dataSourceSonarqubeQualityProfileExportRead",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileExportRead: Failed to read response body: %+v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%v/%v", qualityProfile.Key, d.Get("exporter_key").(string)))
+	d.Set("key", qualityProfile.Key)
+	d.Set("name", qualityProfile.Name)
+	d.Set("language", qualityProfile.Language)
+	d.Set("content", string(content))
+	return nil
+}
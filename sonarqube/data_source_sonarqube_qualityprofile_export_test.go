@@ -0,0 +1,46 @@
+package sonarqube
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeQualityProfileExportDataSourceConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "java"
+		}
+
+		data "sonarqube_qualityprofile_export" "%[1]s" {
+			key = sonarqube_qualityprofile.%[1]s.key
+		}
+
+		data "sonarqube_qualityprofile_exporters" "%[1]s" {
+			language = "java"
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeQualityProfileExportDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_qualityprofile_export." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileExportDataSourceConfig(rnd, "testAccSonarqubeQualityProfileExportDataSource"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeQualityProfileExportDataSource"),
+					resource.TestCheckResourceAttr(name, "language", "java"),
+					resource.TestMatchResourceAttr(name, "content", regexp.MustCompile("<name>testAccSonarqubeQualityProfileExportDataSource</name>")),
+					resource.TestCheckResourceAttrSet("data.sonarqube_qualityprofile_exporters."+rnd, "exporters.#"),
+				),
+			},
+		},
+	})
+}
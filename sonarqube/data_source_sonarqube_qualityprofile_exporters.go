@@ -0,0 +1,100 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GetQualityProfileExporters for unmarshalling response body of api/qualityprofiles/exporters
+type GetQualityProfileExporters struct {
+	Exporters []QualityProfileExporter `json:"exporters"`
+}
+
+// QualityProfileExporter used in GetQualityProfileExporters
+type QualityProfileExporter struct {
+	Key       string   `json:"key"`
+	Name      string   `json:"name"`
+	Languages []string `json:"languages"`
+}
+
+func dataSourceSonarqubeQualityProfileExporters() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeQualityProfileExportersRead,
+		Schema: map[string]*schema.Schema{
+			"language": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the exporters supporting this language",
+			},
+			"exporters": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The available quality profile exporters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"languages": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeQualityProfileExportersRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/exporters"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"dataSourceSonarqubeQualityProfileExportersRead",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	exportersResponse := GetQualityProfileExporters{}
+	err = json.NewDecoder(resp.Body).Decode(&exportersResponse)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileExportersRead: Failed to decode json into struct: %+v", err)
+	}
+
+	language := d.Get("language").(string)
+	exporters := make([]interface{}, 0)
+	for _, exporter := range exportersResponse.Exporters {
+		if language != "" && !containsStringFold(exporter.Languages, language) {
+			continue
+		}
+		exporters = append(exporters, map[string]interface{}{
+			"key":       exporter.Key,
+			"name":      exporter.Name,
+			"languages": exporter.Languages,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("exporters/%v", language))
+	d.Set("exporters", exporters)
+	return nil
+}
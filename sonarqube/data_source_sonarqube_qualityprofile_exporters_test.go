@@ -0,0 +1,78 @@
+package sonarqube
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccSonarqubeQualityProfileExportersDataSourceConfig(rnd string) string {
+	return fmt.Sprintf(`
+		data "sonarqube_qualityprofile_exporters" "%[1]s_all" {}
+
+		data "sonarqube_qualityprofile_exporters" "%[1]s" {
+			language = "cs"
+		}`, rnd)
+}
+
+func TestAccSonarqubeQualityProfileExportersDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_qualityprofile_exporters." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileExportersDataSourceConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "exporters/cs"),
+					resource.TestCheckResourceAttr(name+"_all", "id", "exporters/"),
+					testAccCheckSonarqubeQualityProfileExportersLanguage(name, name+"_all", "cs"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSonarqubeQualityProfileExportersLanguage checks that the filtered exporters all support the language
+// and are a subset of the unfiltered exporters
+func testAccCheckSonarqubeQualityProfileExportersLanguage(name string, allName string, language string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		filtered, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("data source not found: %s", name)
+		}
+		all, ok := s.RootModule().Resources[allName]
+		if !ok {
+			return fmt.Errorf("data source not found: %s", allName)
+		}
+
+		allKeys := make([]string, 0)
+		allCount, _ := strconv.Atoi(all.Primary.Attributes["exporters.#"])
+		for i := 0; i < allCount; i++ {
+			allKeys = append(allKeys, all.Primary.Attributes[fmt.Sprintf("exporters.%d.key", i)])
+		}
+
+		count, _ := strconv.Atoi(filtered.Primary.Attributes["exporters.#"])
+		for i := 0; i < count; i++ {
+			key := filtered.Primary.Attributes[fmt.Sprintf("exporters.%d.key", i)]
+			if !containsStringFold(allKeys, key) {
+				return fmt.Errorf("exporter '%s' is missing from the unfiltered exporters", key)
+			}
+
+			languages := make([]string, 0)
+			languageCount, _ := strconv.Atoi(filtered.Primary.Attributes[fmt.Sprintf("exporters.%d.languages.#", i)])
+			for j := 0; j < languageCount; j++ {
+				languages = append(languages, filtered.Primary.Attributes[fmt.Sprintf("exporters.%d.languages.%d", i, j)])
+			}
+			if !containsStringFold(languages, language) {
+				return fmt.Errorf("exporter '%s' does not support language '%s': %v", key, language, languages)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,99 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GetRuleRepositories for unmarshalling response body of api/rules/repositories
+type GetRuleRepositories struct {
+	Repositories []RuleRepository `json:"repositories"`
+}
+
+// RuleRepository used in GetRuleRepositories
+type RuleRepository struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Language string `json:"language"`
+}
+
+func dataSourceSonarqubeRuleRepositories() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeRuleRepositoriesRead,
+		Schema: map[string]*schema.Schema{
+			"language": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the repositories of this language",
+			},
+			"repositories": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The rule repositories",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"language": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeRuleRepositoriesRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/rules/repositories"
+	if language, ok := d.GetOk("language"); ok {
+		sonarQubeURL.RawQuery = url.Values{
+			"language": []string{language.(string)},
+		}.Encode()
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"dataSourceSonarqubeRuleRepositoriesRead",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	repositoriesResponse := GetRuleRepositories{}
+	err = json.NewDecoder(resp.Body).Decode(&repositoriesResponse)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeRuleRepositoriesRead: Failed to decode json into struct: %+v", err)
+	}
+
+	repositories := make([]interface{}, len(repositoriesResponse.Repositories))
+	for i, repository := range repositoriesResponse.Repositories {
+		repositories[i] = map[string]interface{}{
+			"key":      repository.Key,
+			"name":     repository.Name,
+			"language": repository.Language,
+		}
+	}
+
+	d.SetId(fmt.Sprintf("repositories/%v", d.Get("language").(string)))
+	d.Set("repositories", repositories)
+	return nil
+}
@@ -0,0 +1,69 @@
+package sonarqube
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccSonarqubeRuleRepositoriesDataSourceConfig(rnd string) string {
+	return fmt.Sprintf(`
+		data "sonarqube_rule_repositories" "%[1]s_all" {}
+
+		data "sonarqube_rule_repositories" "%[1]s" {
+			language = "java"
+		}`, rnd)
+}
+
+func TestAccSonarqubeRuleRepositoriesDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_rule_repositories." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeRuleRepositoriesDataSourceConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "repositories/java"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "repositories.*", map[string]string{
+						"key":      "java",
+						"language": "java",
+					}),
+					testAccCheckSonarqubeRuleRepositoriesLanguage(name, "java"),
+					resource.TestCheckResourceAttr(name+"_all", "id", "repositories/"),
+					resource.TestCheckTypeSetElemNestedAttrs(name+"_all", "repositories.*", map[string]string{
+						"key":      "java",
+						"language": "java",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(name+"_all", "repositories.*", map[string]string{
+						"key":      "xml",
+						"language": "xml",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSonarqubeRuleRepositoriesLanguage checks that all repositories belong to the language
+func testAccCheckSonarqubeRuleRepositoriesLanguage(name string, language string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("data source not found: %s", name)
+		}
+
+		count, _ := strconv.Atoi(rs.Primary.Attributes["repositories.#"])
+		for i := 0; i < count; i++ {
+			if value := rs.Primary.Attributes[fmt.Sprintf("repositories.%d.language", i)]; value != language {
+				return fmt.Errorf("repository '%s' has language '%s', expected '%s'", rs.Primary.Attributes[fmt.Sprintf("repositories.%d.key", i)], value, language)
+			}
+		}
+		return nil
+	}
+}
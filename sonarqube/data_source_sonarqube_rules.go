@@ -0,0 +1,249 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// rulesSearchFilters maps the list filter attributes of the data source to the parameters of api/rules/search
+var rulesSearchFilters = map[string]string{
+	"languages":            "languages",
+	"repositories":         "repositories",
+	"tags":                 "tags",
+	"types":                "types",
+	"severities":           "severities",
+	"statuses":             "statuses",
+	"cwe":                  "cwe",
+	"owasp_top10":          "owaspTop10",
+	"sonarsource_security": "sonarsourceSecurity",
+}
+
+// rulesSearchResultLimit is the maximum number of results api/rules/search returns, later pages are rejected
+const rulesSearchResultLimit = 10000
+
+func dataSourceSonarqubeRules() *schema.Resource {
+	dataSourceSchema := map[string]*schema.Schema{
+		"is_template": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Filter template rules (true) or rules that are no templates (false)",
+		},
+		"template_key": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Only return the custom rules created from this template",
+		},
+		"qprofile": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Key of a quality profile to filter on, together with activation",
+		},
+		"activation": {
+			Type:         schema.TypeBool,
+			Optional:     true,
+			RequiredWith: []string{"qprofile"},
+			Description:  "Return the rules that are active (true) or inactive (false) in the quality profile qprofile",
+		},
+		"total": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of rules matching the filters",
+		},
+		"rules": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The rules matching the filters",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"key": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"repository": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"severity": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"type": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"status": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"language": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"is_template": {
+						Type:     schema.TypeBool,
+						Computed: true,
+					},
+					"template_key": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"tags": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"params": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"key": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"type": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"default_value": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for attribute := range rulesSearchFilters {
+		dataSourceSchema[attribute] = &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceSonarqubeRulesRead,
+		Schema: dataSourceSchema,
+	}
+}
+
+func dataSourceSonarqubeRulesRead(d *schema.ResourceData, m interface{}) error {
+	query := url.Values{
+		"f": []string{"name,repo,severity,status,lang,isTemplate,templateKey,tags,sysTags,params,type"},
+		"s": []string{"key"},
+	}
+	for attribute, parameter := range rulesSearchFilters {
+		if values := expandStringSet(d.Get(attribute).(*schema.Set)); len(values) > 0 {
+			query.Set(parameter, strings.Join(values, ","))
+		}
+	}
+	// Booleans are only sent when configured, as false is a meaningful filter value
+	if isTemplate := d.GetRawConfig().GetAttr("is_template"); !isTemplate.IsNull() {
+		query.Set("is_template", strconv.FormatBool(isTemplate.True()))
+	}
+	if templateKey, ok := d.GetOk("template_key"); ok {
+		query.Set("template_key", templateKey.(string))
+	}
+	if qprofile, ok := d.GetOk("qprofile"); ok {
+		query.Set("qprofile", qprofile.(string))
+	}
+	if activation := d.GetRawConfig().GetAttr("activation"); !activation.IsNull() {
+		query.Set("activation", strconv.FormatBool(activation.True()))
+	}
+
+	rules := make([]interface{}, 0)
+	total := 0
+	page := int64(1)
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/rules/search"
+		query.Set("p", strconv.FormatInt(page, 10))
+		query.Set("ps", "500")
+		sonarQubeURL.RawQuery = query.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"dataSourceSonarqubeRulesRead",
+		)
+		if err != nil {
+			return err
+		}
+		// Decode response into struct
+		searchResponse := GetRule{}
+		err = json.NewDecoder(resp.Body).Decode(&searchResponse)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("dataSourceSonarqubeRulesRead: Failed to decode json into struct: %+v", err)
+		}
+
+		total = searchResponse.Total
+		if total > rulesSearchResultLimit {
+			return fmt.Errorf("dataSourceSonarqubeRulesRead: %d rules match the filters, but SonarQube returns at most %d results. Narrow the filters, e.g. with languages or repositories", total, rulesSearchResultLimit)
+		}
+		for _, rule := range searchResponse.Rule {
+			rules = append(rules, flattenRulesSearchRule(rule))
+		}
+
+		// Stop once the last page has been read
+		if len(searchResponse.Rule) == 0 || searchResponse.P*searchResponse.PS >= searchResponse.Total {
+			break
+		}
+		page++
+	}
+
+	query.Del("p")
+	query.Del("ps")
+	d.SetId(query.Encode())
+	d.Set("total", total)
+	d.Set("rules", rules)
+	return nil
+}
+
+func flattenRulesSearchRule(rule Rule) map[string]interface{} {
+	tags := make([]string, 0, len(rule.SysTags)+len(rule.Tags))
+	tags = append(tags, rule.SysTags...)
+	tags = append(tags, rule.Tags...)
+
+	params := make([]interface{}, len(rule.Params))
+	for i, param := range rule.Params {
+		params[i] = map[string]interface{}{
+			"key":           param.ParmKey,
+			"type":          param.Type,
+			"default_value": param.DefaultValue,
+		}
+	}
+
+	return map[string]interface{}{
+		"key":          rule.RuleKey,
+		"name":         rule.Name,
+		"repository":   rule.Repo,
+		"severity":     rule.Severity,
+		"type":         rule.Type,
+		"status":       rule.Status,
+		"language":     rule.Lang,
+		"is_template":  rule.IsTemplate,
+		"template_key": rule.TemplateKey,
+		"tags":         tags,
+		"params":       params,
+	}
+}
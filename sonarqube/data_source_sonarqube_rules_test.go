@@ -0,0 +1,43 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeRulesDataSourceConfig(rnd string) string {
+	return fmt.Sprintf(`
+		data "sonarqube_rules" "%[1]s" {
+			languages   = ["java"]
+			types       = ["VULNERABILITY"]
+			is_template = false
+		}
+
+		data "sonarqube_rules" "%[1]s_templates" {
+			repositories = ["java"]
+			is_template  = true
+		}`, rnd)
+}
+
+func TestAccSonarqubeRulesDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_rules." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeRulesDataSourceConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "total"),
+					resource.TestCheckResourceAttr(name, "rules.0.language", "java"),
+					resource.TestCheckResourceAttr(name, "rules.0.type", "VULNERABILITY"),
+					resource.TestCheckResourceAttr(name+"_templates", "rules.0.is_template", "true"),
+				),
+			},
+		},
+	})
+}
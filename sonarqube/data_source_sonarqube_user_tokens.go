@@ -0,0 +1,109 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSonarqubeUserTokens() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeUserTokensRead,
+		Schema: map[string]*schema.Schema{
+			"login_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The login of the user. Defaults to the authenticated user",
+			},
+			"user_tokens": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The tokens of the user",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"project_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_connection_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"expiration_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_expired": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeUserTokensRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_tokens/search"
+	if login, ok := d.GetOk("login_name"); ok {
+		sonarQubeURL.RawQuery = url.Values{
+			"login": []string{login.(string)},
+		}.Encode()
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"dataSourceSonarqubeUserTokensRead",
+	)
+	if err != nil {
+		return fmt.Errorf("error reading Sonarqube user tokens: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	getTokensResponse := GetTokens{}
+	err = json.NewDecoder(resp.Body).Decode(&getTokensResponse)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeUserTokensRead: Failed to decode json into struct: %+v", err)
+	}
+
+	userTokens := make([]interface{}, len(getTokensResponse.Tokens))
+	for i, value := range getTokensResponse.Tokens {
+		userTokens[i] = map[string]interface{}{
+			"name":                 value.Name,
+			"type":                 value.Type,
+			"project_key":          value.Project.Key,
+			"created_at":           value.CreatedAt,
+			"last_connection_date": value.LastConnectionDate,
+			"expiration_date":      value.ExpirationDate,
+			"is_expired":           value.IsExpired,
+		}
+	}
+
+	d.SetId(getTokensResponse.Login)
+	d.Set("login_name", getTokensResponse.Login)
+	d.Set("user_tokens", userTokens)
+	return nil
+}
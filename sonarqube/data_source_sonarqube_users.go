@@ -0,0 +1,201 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// usersSearchFiltersSince10 maps the filter attributes that require SonarQube 10.1 to the parameters of api/users/search
+var usersSearchFiltersSince10 = map[string]string{
+	"external_identity":     "externalIdentity",
+	"last_connected_after":  "lastConnectedAfter",
+	"last_connected_before": "lastConnectedBefore",
+}
+
+func dataSourceSonarqubeUsers() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeUsersRead,
+		Schema: map[string]*schema.Schema{
+			"search": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filter on login, name and email",
+			},
+			"deactivated": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Return deactivated users instead of active users",
+			},
+			"managed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Return only managed (true) or only non-managed (false) users. Requires SonarQube 10.1",
+			},
+			"external_identity": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filter on the identity of the user in the external identity provider. Requires SonarQube 10.1",
+			},
+			"last_connected_after": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return users that connected at or after this date (yyyy-MM-ddTHH:mm:ssZ). Requires SonarQube 10.1",
+			},
+			"last_connected_before": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return users that never connected or connected before this date (yyyy-MM-ddTHH:mm:ssZ). Requires SonarQube 10.1",
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The users matching the filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"login_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_local": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_active": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"managed": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"groups": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"scm_accounts": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"external_identity": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"external_provider": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_connection_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeUsersRead(d *schema.ResourceData, m interface{}) error {
+	conf := m.(*ProviderConfiguration)
+	minimumVersion, _ := version.NewVersion("10.1")
+
+	query := url.Values{
+		"deactivated": []string{strconv.FormatBool(d.Get("deactivated").(bool))},
+	}
+	if search, ok := d.GetOk("search"); ok {
+		query.Set("q", search.(string))
+	}
+	for attribute, parameter := range usersSearchFiltersSince10 {
+		if value, ok := d.GetOk(attribute); ok {
+			if conf.sonarQubeVersion.LessThan(minimumVersion) {
+				return fmt.Errorf("dataSourceSonarqubeUsersRead: Minimum required SonarQube version for the %s filter is %s", attribute, minimumVersion)
+			}
+			query.Set(parameter, value.(string))
+		}
+	}
+	// Booleans are only sent when configured, as false is a meaningful filter value
+	if managed := d.GetRawConfig().GetAttr("managed"); !managed.IsNull() {
+		if conf.sonarQubeVersion.LessThan(minimumVersion) {
+			return fmt.Errorf("dataSourceSonarqubeUsersRead: Minimum required SonarQube version for the managed filter is %s", minimumVersion)
+		}
+		query.Set("managed", strconv.FormatBool(managed.True()))
+	}
+
+	users := make([]interface{}, 0)
+	page := int64(1)
+	for {
+		sonarQubeURL := conf.sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/search"
+		query.Set("p", strconv.FormatInt(page, 10))
+		query.Set("ps", "500")
+		sonarQubeURL.RawQuery = query.Encode()
+
+		resp, err := httpRequestHelper(
+			conf.httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"dataSourceSonarqubeUsersRead",
+		)
+		if err != nil {
+			return err
+		}
+		// Decode response into struct
+		userResponse := GetUser{}
+		err = json.NewDecoder(resp.Body).Decode(&userResponse)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("dataSourceSonarqubeUsersRead: Failed to decode json into struct: %+v", err)
+		}
+
+		for _, value := range userResponse.Users {
+			users = append(users, map[string]interface{}{
+				"login_name":           value.Login,
+				"name":                 value.Name,
+				"email":                value.Email,
+				"is_local":             value.IsLocal,
+				"is_active":            value.IsActive,
+				"managed":              value.Managed,
+				"groups":               value.Groups,
+				"scm_accounts":         value.ScmAccounts,
+				"external_identity":    value.ExternalIdentity,
+				"external_provider":    value.ExternalProvider,
+				"last_connection_date": value.LastConnectionDate,
+			})
+		}
+
+		// Stop once the last page has been read
+		if len(userResponse.Users) == 0 || userResponse.Paging.PageIndex*userResponse.Paging.PageSize >= userResponse.Paging.Total {
+			break
+		}
+		page++
+	}
+
+	query.Del("p")
+	query.Del("ps")
+	d.SetId(query.Encode())
+	d.Set("users", users)
+	return nil
+}
@@ -0,0 +1,45 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeUsersDataSourceConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name   = "%[2]s"
+			name         = "%[2]s"
+			email        = "terraform-test-users-data-source@sonarqube.com"
+			password     = "secret-sauce37!"
+			scm_accounts = ["%[2]s-scm"]
+		}
+
+		data "sonarqube_users" "%[1]s" {
+			search = sonarqube_user.%[1]s.login_name
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeUsersDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_users." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUsersDataSourceConfig(rnd, "testAccSonarqubeUsersDataSource"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "users.#", "1"),
+					resource.TestCheckResourceAttr(name, "users.0.login_name", "testAccSonarqubeUsersDataSource"),
+					resource.TestCheckResourceAttr(name, "users.0.email", "terraform-test-users-data-source@sonarqube.com"),
+					resource.TestCheckResourceAttr(name, "users.0.is_local", "true"),
+					resource.TestCheckResourceAttr(name, "users.0.scm_accounts.0", "testAccSonarqubeUsersDataSource-scm"),
+				),
+			},
+		},
+	})
+}
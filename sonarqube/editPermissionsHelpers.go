@@ -0,0 +1,164 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// editPermissionsTarget identifies a quality gate or quality profile whose edit permissions are managed through
+// the search_users, search_groups, add_user, remove_user, add_group and remove_group endpoints of its web service
+type editPermissionsTarget struct {
+	// description is used in error messages, e.g. "quality gate 'my-gate'"
+	description string
+	// service is the web service of the endpoints, e.g. "qualitygates"
+	service string
+	// params identify the quality gate or quality profile in every request
+	params url.Values
+	// groupParam is the name of the group parameter of add_group and remove_group
+	groupParam string
+}
+
+// qualityGateEditPermissionsTarget returns the edit permissions target of a quality gate
+func qualityGateEditPermissionsTarget(gateName string) editPermissionsTarget {
+	return editPermissionsTarget{
+		description: fmt.Sprintf("quality gate '%s'", gateName),
+		service:     "qualitygates",
+		params: url.Values{
+			"gateName": []string{gateName},
+		},
+		groupParam: "groupName",
+	}
+}
+
+// qualityProfileEditPermissionsTarget returns the edit permissions target of a quality profile
+func qualityProfileEditPermissionsTarget(profileName string, language string) editPermissionsTarget {
+	return editPermissionsTarget{
+		description: fmt.Sprintf("quality profile '%s'", profileName),
+		service:     "qualityprofiles",
+		params: url.Values{
+			"qualityProfile": []string{profileName},
+			"language":       []string{language},
+		},
+		groupParam: "group",
+	}
+}
+
+// readEditPermissionsFromApi returns the logins of the users and the names of the groups allowed to edit the target.
+// Both are nil when the target does not exist.
+func readEditPermissionsFromApi(target editPermissionsTarget, m interface{}) ([]string, []string, error) {
+	users := make([]string, 0)
+	groups := make([]string, 0)
+
+	for _, targetType := range []string{"user", "group"} {
+		page := int64(1)
+		for {
+			sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/" + target.service + "/search_" + targetType + "s"
+			rawQuery := url.Values{
+				"selected": []string{"selected"},
+				"p":        []string{strconv.FormatInt(page, 10)},
+				"ps":       []string{"100"},
+			}
+			for key, values := range target.params {
+				rawQuery[key] = values
+			}
+			sonarQubeURL.RawQuery = rawQuery.Encode()
+
+			resp, err := httpRequestHelper(
+				m.(*ProviderConfiguration).httpClient,
+				"GET",
+				sonarQubeURL.String(),
+				http.StatusOK,
+				"readEditPermissionsFromApi",
+			)
+			if resp.StatusCode == http.StatusNotFound {
+				return nil, nil, nil
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Decode response into struct
+			permissionsResponse := GetQualityGateUsergroupAssociation{}
+			err = json.NewDecoder(resp.Body).Decode(&permissionsResponse)
+			resp.Body.Close()
+			if err != nil {
+				return nil, nil, fmt.Errorf("readEditPermissionsFromApi: Failed to decode json into struct: %+v", err)
+			}
+
+			for _, value := range permissionsResponse.Users {
+				users = append(users, value.Login)
+			}
+			for _, value := range permissionsResponse.Groups {
+				groups = append(groups, value.Name)
+			}
+
+			// Stop once the last page has been read
+			if permissionsResponse.Paging.PageIndex*permissionsResponse.Paging.PageSize >= permissionsResponse.Paging.Total {
+				break
+			}
+			page++
+		}
+	}
+
+	return users, groups, nil
+}
+
+// synchronizeEditPermissions adds and removes users or groups so that the target matches the desired list
+func synchronizeEditPermissions(target editPermissionsTarget, targetType string, current []string, desired []string, m interface{}) error {
+	for _, value := range desired {
+		if !containsStringFold(current, value) {
+			if err := setEditPermission(target, targetType, value, true, m); err != nil {
+				return fmt.Errorf("Failed to add %s '%s' to %s: %+v", targetType, value, target.description, err)
+			}
+		}
+	}
+
+	for _, value := range current {
+		if !containsStringFold(desired, value) {
+			if err := setEditPermission(target, targetType, value, false, m); err != nil {
+				return fmt.Errorf("Failed to remove %s '%s' from %s: %+v", targetType, value, target.description, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func setEditPermission(target editPermissionsTarget, targetType string, value string, add bool, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	action := "remove"
+	if add {
+		action = "add"
+	}
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/" + target.service + "/" + action + "_" + targetType
+
+	rawQuery := url.Values{}
+	for key, values := range target.params {
+		rawQuery[key] = values
+	}
+	if targetType == "user" {
+		rawQuery.Add("login", value)
+	} else {
+		rawQuery.Add(target.groupParam, value)
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"setEditPermission",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 // ErrorResponse struct
 type ErrorResponse struct {
 	Errors []ErrorMessage `json:"errors,omitempty"`
+	// Message is used by the v2 API instead of Errors
+	Message string `json:"message,omitempty"`
 }
 
 // ErrorMessage struct
@@ -27,11 +29,19 @@ type Paging struct {
 
 // helper function to make api request to sonarqube
 func httpRequestHelper(client *retryablehttp.Client, method string, sonarqubeURL string, expectedResponseCode int, errormsg string) (http.Response, error) {
+	return httpRequestBodyHelper(client, method, sonarqubeURL, http.NoBody, "", expectedResponseCode, errormsg)
+}
+
+// helper function to make api request with a request body to sonarqube
+func httpRequestBodyHelper(client *retryablehttp.Client, method string, sonarqubeURL string, body interface{}, contentType string, expectedResponseCode int, errormsg string) (http.Response, error) {
 	// Prepare request
-	req, err := retryablehttp.NewRequest(method, sonarqubeURL, http.NoBody)
+	req, err := retryablehttp.NewRequest(method, sonarqubeURL, body)
 	if err != nil {
 		return http.Response{}, fmt.Errorf("failed to prepare http request: %v. Request: %v", err, req)
 	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 
 	// Execute request
 	resp, err := client.Do(req)
@@ -52,8 +62,20 @@ func httpRequestHelper(client *retryablehttp.Client, method string, sonarqubeURL
 		if err != nil {
 			return *resp, fmt.Errorf("failed to decode error response json into struct: %+v", err)
 		}
+		if len(errorResponse.Errors) == 0 {
+			return *resp, fmt.Errorf("API returned an error: %+v", errorResponse.Message)
+		}
 		return *resp, fmt.Errorf("API returned an error: %+v", errorResponse.Errors[0].Message)
 	}
 
 	return *resp, nil
 }
+
+// helper function to make api request with a json request body to sonarqube
+func httpRequestJSONHelper(client *retryablehttp.Client, method string, sonarqubeURL string, body interface{}, contentType string, expectedResponseCode int, errormsg string) (http.Response, error) {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return http.Response{}, fmt.Errorf("failed to encode request body as json: %+v", err)
+	}
+	return httpRequestBodyHelper(client, method, sonarqubeURL, jsonBody, contentType, expectedResponseCode, errormsg)
+}
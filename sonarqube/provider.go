@@ -80,6 +80,7 @@ func Provider() *schema.Provider {
 			"sonarqube_azure_binding":                      resourceSonarqubeAzureBinding(),
 			"sonarqube_group":                              resourceSonarqubeGroup(),
 			"sonarqube_group_member":                       resourceSonarqubeGroupMember(),
+			"sonarqube_group_members":                      resourceSonarqubeGroupMembers(),
 			"sonarqube_permission_template":                resourceSonarqubePermissionTemplate(),
 			"sonarqube_permissions":                        resourceSonarqubePermissions(),
 			"sonarqube_plugin":                             resourceSonarqubePlugin(),
@@ -88,31 +89,52 @@ func Provider() *schema.Provider {
 			"sonarqube_portfolio":                          resourceSonarqubePortfolio(),
 			"sonarqube_qualityprofile":                     resourceSonarqubeQualityProfile(),
 			"sonarqube_qualityprofile_project_association": resourceSonarqubeQualityProfileProjectAssociation(),
+			"sonarqube_qualityprofile_backup":              resourceSonarqubeQualityProfileBackup(),
+			"sonarqube_qualityprofile_default":             resourceSonarqubeQualityProfileDefault(),
+			"sonarqube_qualityprofile_permissions":         resourceSonarqubeQualityProfilePermissions(),
 			"sonarqube_qualitygate":                        resourceSonarqubeQualityGate(),
 			"sonarqube_qualitygate_project_association":    resourceSonarqubeQualityGateProjectAssociation(),
 			"sonarqube_qualitygate_usergroup_association":  resourceSonarqubeQualityGateUsergroupAssociation(),
+			"sonarqube_qualitygate_permissions":            resourceSonarqubeQualityGatePermissions(),
 			"sonarqube_user":                               resourceSonarqubeUser(),
 			"sonarqube_user_external_identity":             resourceSonarqubeUserExternalIdentity(),
 			"sonarqube_user_token":                         resourceSonarqubeUserToken(),
 			"sonarqube_webhook":                            resourceSonarqubeWebhook(),
 			"sonarqube_rule":                               resourceSonarqubeRule(),
+			"sonarqube_rule_metadata":                      resourceSonarqubeRuleMetadata(),
 			"sonarqube_setting":                            resourceSonarqubeSettings(),
+			"sonarqube_saml_authentication":                resourceSonarqubeSamlAuthentication(),
 			"sonarqube_qualityprofile_activate_rule":       resourceSonarqubeQualityProfileRule(),
+			"sonarqube_qualityprofile_rules":               resourceSonarqubeQualityProfileRules(),
+			"sonarqube_qualityprofile_rule_query":          resourceSonarqubeQualityProfileRuleQuery(),
 			"sonarqube_alm_github":                         resourceSonarqubeAlmGithub(),
+			"sonarqube_github_authentication":              resourceSonarqubeGithubAuthentication(),
 			"sonarqube_github_binding":                     resourceSonarqubeGithubBinding(),
 			"sonarqube_alm_gitlab":                         resourceSonarqubeAlmGitlab(),
+			"sonarqube_gitlab_authentication":              resourceSonarqubeGitlabAuthentication(),
 			"sonarqube_gitlab_binding":                     resourceSonarqubeGitlabBinding(),
 			"sonarqube_new_code_periods":                   resourceSonarqubeNewCodePeriodsBinding(),
 			"sonarqube_portfolio_hierarchy":                resourceSonarqubePortfolioHierarchy(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"sonarqube_user":           dataSourceSonarqubeUser(),
-			"sonarqube_group":          dataSourceSonarqubeGroup(),
-			"sonarqube_project":        dataSourceSonarqubeProject(),
-			"sonarqube_portfolio":      dataSourceSonarqubePortfolio(),
-			"sonarqube_qualityprofile": dataSourceSonarqubeQualityProfile(),
-			"sonarqube_qualitygate":    dataSourceSonarqubeQualityGate(),
-			"sonarqube_rule":           dataSourceSonarqubeRule(),
+			"sonarqube_user":                      dataSourceSonarqubeUser(),
+			"sonarqube_user_tokens":               dataSourceSonarqubeUserTokens(),
+			"sonarqube_users":                     dataSourceSonarqubeUsers(),
+			"sonarqube_group":                     dataSourceSonarqubeGroup(),
+			"sonarqube_project":                   dataSourceSonarqubeProject(),
+			"sonarqube_project_qualityprofiles":   dataSourceSonarqubeProjectQualityProfiles(),
+			"sonarqube_project_qualitygate":       dataSourceSonarqubeProjectQualityGate(),
+			"sonarqube_portfolio":                 dataSourceSonarqubePortfolio(),
+			"sonarqube_qualityprofile":            dataSourceSonarqubeQualityProfile(),
+			"sonarqube_qualityprofile_comparison": dataSourceSonarqubeQualityProfileComparison(),
+			"sonarqube_qualityprofile_changelog":  dataSourceSonarqubeQualityProfileChangelog(),
+			"sonarqube_qualityprofile_export":     dataSourceSonarqubeQualityProfileExport(),
+			"sonarqube_qualityprofile_exporters":  dataSourceSonarqubeQualityProfileExporters(),
+			"sonarqube_qualitygate":               dataSourceSonarqubeQualityGate(),
+			"sonarqube_rule":                      dataSourceSonarqubeRule(),
+			"sonarqube_rules":                     dataSourceSonarqubeRules(),
+			"sonarqube_languages":                 dataSourceSonarqubeLanguages(),
+			"sonarqube_rule_repositories":         dataSourceSonarqubeRuleRepositories(),
 		},
 		ConfigureFunc: configureProvider,
 	}
@@ -0,0 +1,315 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// GithubConfigurationV2 for unmarshalling response body of api/v2/dop-translation/github-configurations
+type GithubConfigurationV2 struct {
+	ID                   string   `json:"id"`
+	Enabled              bool     `json:"enabled"`
+	ApplicationID        string   `json:"applicationId"`
+	SynchronizeGroups    bool     `json:"synchronizeGroups"`
+	ApiURL               string   `json:"apiUrl"`
+	WebURL               string   `json:"webUrl"`
+	AllowedOrganizations []string `json:"allowedOrganizations"`
+	ProvisioningType     string   `json:"provisioningType"`
+	AllowUsersToSignUp   bool     `json:"allowUsersToSignUp"`
+}
+
+// githubAuthenticationSettings maps the attributes to the settings used before api/v2/dop-translation.
+// The enabled flag is written last, once the rest of the configuration is in place.
+var githubAuthenticationSettings = []settingAttribute{
+	{attribute: "client_id", key: "sonar.auth.github.clientId.secured", secured: true},
+	{attribute: "client_secret", key: "sonar.auth.github.clientSecret.secured", secured: true},
+	{attribute: "app_id", key: "sonar.auth.github.appId"},
+	{attribute: "private_key", key: "sonar.auth.github.privateKey.secured", secured: true},
+	{attribute: "api_url", key: "sonar.auth.github.apiUrl"},
+	{attribute: "web_url", key: "sonar.auth.github.webUrl"},
+	{attribute: "allowed_organizations", key: "sonar.auth.github.organizations"},
+	{attribute: "synchronize_groups", key: "sonar.auth.github.groupsSync"},
+	{attribute: "allow_users_to_sign_up", key: "sonar.auth.github.allowUsersToSignUp"},
+	{attribute: "enabled", key: "sonar.auth.github.enabled"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeGithubAuthentication() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSonarqubeGithubAuthenticationCreate,
+		Read:   resourceSonarqubeGithubAuthenticationRead,
+		Update: resourceSonarqubeGithubAuthenticationUpdate,
+		Delete: resourceSonarqubeGithubAuthenticationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeGithubAuthenticationImport,
+		},
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+				return validateProvisioningType(d, m, "app_id", "private_key")
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enable GitHub authentication",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The client ID of the GitHub App",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The client secret of the GitHub App",
+			},
+			"app_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of the GitHub App. Required for automatic provisioning",
+			},
+			"private_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The private key of the GitHub App. Required for automatic provisioning",
+			},
+			"api_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "https://api.github.com/",
+				Description: "The API url of the GitHub instance",
+			},
+			"web_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "https://github.com/",
+				Description: "The web url of the GitHub instance",
+			},
+			"allowed_organizations": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Only members of these organizations are allowed to authenticate",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"synchronize_groups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Synchronize the GitHub teams of a user with the SonarQube groups of the same name",
+			},
+			"allow_users_to_sign_up": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Allow new users to authenticate. When false, only existing users can authenticate",
+			},
+			"provisioning_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "JIT",
+				Description: "How users and groups are provisioned: JIT (on sign in) or AUTO_PROVISIONING. AUTO_PROVISIONING requires SonarQube 10.5",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{"JIT", "AUTO_PROVISIONING"}, false),
+				),
+			},
+		},
+	}
+}
+
+func resourceSonarqubeGithubAuthenticationCreate(d *schema.ResourceData, m interface{}) error {
+	if !dopTranslationV2ApiSupported(m) {
+		if err := setSettingAttributes(d, githubAuthenticationSettings, true, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeGithubAuthenticationCreate: %+v", err)
+		}
+		d.SetId("github")
+		return resourceSonarqubeGithubAuthenticationRead(d, m)
+	}
+
+	configuration := githubConfigurationV2Body(d, true)
+	resp, err := httpRequestJSONHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarqubeV2URL(m, "dop-translation/github-configurations", nil),
+		configuration,
+		contentTypeJSON,
+		http.StatusOK,
+		"resourceSonarqubeGithubAuthenticationCreate",
+	)
+	if err != nil {
+		return fmt.Errorf("error creating Sonarqube GitHub configuration: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	configurationResponse := GithubConfigurationV2{}
+	err = json.NewDecoder(resp.Body).Decode(&configurationResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGithubAuthenticationCreate: Failed to decode json into struct: %+v", err)
+	}
+	d.SetId(configurationResponse.ID)
+
+	return resourceSonarqubeGithubAuthenticationRead(d, m)
+}
+
+func resourceSonarqubeGithubAuthenticationRead(d *schema.ResourceData, m interface{}) error {
+	if !dopTranslationV2ApiSupported(m) {
+		if err := readSettingAttributes(d, githubAuthenticationSettings, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeGithubAuthenticationRead: %+v", err)
+		}
+		d.Set("provisioning_type", "JIT")
+		return nil
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarqubeV2URL(m, "dop-translation/github-configurations/"+url.PathEscape(d.Id()), nil),
+		http.StatusOK,
+		"resourceSonarqubeGithubAuthenticationRead",
+	)
+	if resp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading Sonarqube GitHub configuration: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	configuration := GithubConfigurationV2{}
+	err = json.NewDecoder(resp.Body).Decode(&configuration)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGithubAuthenticationRead: Failed to decode json into struct: %+v", err)
+	}
+
+	// The client id and secrets are never returned by the api
+	d.Set("enabled", configuration.Enabled)
+	d.Set("app_id", configuration.ApplicationID)
+	d.Set("api_url", configuration.ApiURL)
+	d.Set("web_url", configuration.WebURL)
+	d.Set("allowed_organizations", configuration.AllowedOrganizations)
+	d.Set("synchronize_groups", configuration.SynchronizeGroups)
+	d.Set("allow_users_to_sign_up", configuration.AllowUsersToSignUp)
+	d.Set("provisioning_type", configuration.ProvisioningType)
+	return nil
+}
+
+func resourceSonarqubeGithubAuthenticationUpdate(d *schema.ResourceData, m interface{}) error {
+	if !dopTranslationV2ApiSupported(m) {
+		if err := setSettingAttributes(d, githubAuthenticationSettings, false, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeGithubAuthenticationUpdate: %+v", err)
+		}
+		return resourceSonarqubeGithubAuthenticationRead(d, m)
+	}
+
+	resp, err := httpRequestJSONHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"PATCH",
+		sonarqubeV2URL(m, "dop-translation/github-configurations/"+url.PathEscape(d.Id()), nil),
+		githubConfigurationV2Body(d, false),
+		contentTypeJSONMerge,
+		http.StatusOK,
+		"resourceSonarqubeGithubAuthenticationUpdate",
+	)
+	if err != nil {
+		return fmt.Errorf("error updating Sonarqube GitHub configuration: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return resourceSonarqubeGithubAuthenticationRead(d, m)
+}
+
+func resourceSonarqubeGithubAuthenticationDelete(d *schema.ResourceData, m interface{}) error {
+	if !dopTranslationV2ApiSupported(m) {
+		if err := resetSettingAttributes(githubAuthenticationSettings, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeGithubAuthenticationDelete: %+v", err)
+		}
+		return nil
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"DELETE",
+		sonarqubeV2URL(m, "dop-translation/github-configurations/"+url.PathEscape(d.Id()), nil),
+		http.StatusNoContent,
+		"resourceSonarqubeGithubAuthenticationDelete",
+	)
+	if resp.StatusCode == http.StatusNotFound {
+		// The configuration has already been removed
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error deleting Sonarqube GitHub configuration: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func resourceSonarqubeGithubAuthenticationImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSonarqubeGithubAuthenticationRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// githubConfigurationV2Body returns the request body for api/v2/dop-translation/github-configurations.
+// Secrets are only sent on create or when they changed.
+func githubConfigurationV2Body(d *schema.ResourceData, create bool) map[string]interface{} {
+	configuration := map[string]interface{}{
+		"enabled":              d.Get("enabled").(bool),
+		"applicationId":        d.Get("app_id").(string),
+		"apiUrl":               d.Get("api_url").(string),
+		"webUrl":               d.Get("web_url").(string),
+		"allowedOrganizations": expandStringSet(d.Get("allowed_organizations").(*schema.Set)),
+		"synchronizeGroups":    d.Get("synchronize_groups").(bool),
+		"allowUsersToSignUp":   d.Get("allow_users_to_sign_up").(bool),
+		"provisioningType":     d.Get("provisioning_type").(string),
+	}
+	secrets := map[string]string{
+		"client_id":     "clientId",
+		"client_secret": "clientSecret",
+		"private_key":   "privateKey",
+	}
+	for attribute, field := range secrets {
+		if create || d.HasChange(attribute) {
+			configuration[field] = d.Get(attribute).(string)
+		}
+	}
+	return configuration
+}
+
+// validateProvisioningType checks that automatic provisioning is supported by the server and that the attributes
+// it needs are configured
+func validateProvisioningType(d *schema.ResourceDiff, m interface{}, requiredAttributes ...string) error {
+	if d.Get("provisioning_type").(string) != "AUTO_PROVISIONING" {
+		return nil
+	}
+	if !dopTranslationV2ApiSupported(m) {
+		return fmt.Errorf("provisioning_type AUTO_PROVISIONING requires SonarQube 10.5 or newer. You are using: SonarQube version %s", m.(*ProviderConfiguration).sonarQubeVersion)
+	}
+	for _, attribute := range requiredAttributes {
+		// Unknown values are only known during apply
+		if d.NewValueKnown(attribute) && d.Get(attribute).(string) == "" {
+			return fmt.Errorf("%s is required when provisioning_type is AUTO_PROVISIONING", attribute)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package sonarqube
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_github_authentication", &resource.Sweeper{
+		Name: "sonarqube_github_authentication",
+		F:    testSweepSonarqubeGithubAuthenticationSweeper,
+	})
+}
+
+func testSweepSonarqubeGithubAuthenticationSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeGithubAuthenticationConfig(rnd string, name string, allowSignUp bool) string {
+	return fmt.Sprintf(`
+        resource "sonarqube_github_authentication" "%[1]s" {
+            client_id              = "%[2]s-client"
+            client_secret          = "secret"
+            allowed_organizations  = ["%[2]s"]
+            allow_users_to_sign_up = %[3]t
+        }`, rnd, name, allowSignUp)
+}
+
+func TestAccSonarqubeGithubAuthentication(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_github_authentication." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGithubAuthenticationConfig(rnd, "testAccSonarqubeGithubAuthentication", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+					resource.TestCheckResourceAttr(name, "allow_users_to_sign_up", "true"),
+					resource.TestCheckResourceAttr(name, "allowed_organizations.#", "1"),
+					resource.TestCheckResourceAttr(name, "provisioning_type", "JIT"),
+				),
+			},
+			{
+				Config: testAccSonarqubeGithubAuthenticationConfig(rnd, "testAccSonarqubeGithubAuthentication", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "allow_users_to_sign_up", "false"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The client id and secrets are not returned by SonarQube
+				ImportStateVerifyIgnore: []string{"client_id", "client_secret", "private_key"},
+			},
+		},
+	})
+}
+
+func testAccPreCheckDopTranslationV2Api(t *testing.T) {
+	if !dopTranslationV2ApiSupported(testAccProvider.Meta()) {
+		t.Skipf("Skipping test of api/v2/dop-translation, which requires SonarQube 10.5")
+	}
+}
+
+func testAccSonarqubeGithubAuthenticationProvisioningConfig(rnd string, name string, provisioningType string) string {
+	return fmt.Sprintf(`
+        resource "sonarqube_github_authentication" "%[1]s" {
+            client_id             = "%[2]s-client"
+            client_secret         = "secret"
+            app_id                = "123456"
+            private_key           = "private-key"
+            allowed_organizations = ["%[2]s"]
+            provisioning_type     = "%[3]s"
+        }`, rnd, name, provisioningType)
+}
+
+func TestAccSonarqubeGithubAuthenticationV2(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_github_authentication." + rnd
+	configurationID := ""
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckDopTranslationV2Api(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGithubAuthenticationProvisioningConfig(rnd, "testAccSonarqubeGithubAuthenticationV2", "JIT"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "provisioning_type", "JIT"),
+					resource.TestCheckResourceAttr(name, "app_id", "123456"),
+					func(s *terraform.State) error {
+						configurationID = s.RootModule().Resources[name].Primary.ID
+						if configurationID == "github" {
+							return fmt.Errorf("configuration was created with the settings instead of api/v2/dop-translation")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccSonarqubeGithubAuthenticationProvisioningConfig(rnd, "testAccSonarqubeGithubAuthenticationV2", "AUTO_PROVISIONING"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "provisioning_type", "AUTO_PROVISIONING"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client_id", "client_secret", "private_key"},
+			},
+			{
+				// A configuration deleted outside of terraform is created again, and deleting it again is a no-op
+				PreConfig: func() {
+					d := schema.TestResourceDataRaw(t, resourceSonarqubeGithubAuthentication().Schema, map[string]interface{}{})
+					d.SetId(configurationID)
+					for i := 0; i < 2; i++ {
+						if err := resourceSonarqubeGithubAuthenticationDelete(d, testAccProvider.Meta()); err != nil {
+							t.Fatalf("deleting the GitHub configuration failed: %+v", err)
+						}
+					}
+				},
+				Config: testAccSonarqubeGithubAuthenticationProvisioningConfig(rnd, "testAccSonarqubeGithubAuthenticationV2", "AUTO_PROVISIONING"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "provisioning_type", "AUTO_PROVISIONING"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources[name].Primary.ID == configurationID {
+							return fmt.Errorf("configuration was not created again")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccSonarqubeGithubAuthenticationAutoProvisioningUnsupported(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if dopTranslationV2ApiSupported(testAccProvider.Meta()) {
+				t.Skipf("Skipping test of AUTO_PROVISIONING on SonarQube older than 10.5")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeGithubAuthenticationProvisioningConfig(rnd, "testAccSonarqubeGithubAuthenticationAutoProvisioningUnsupported", "AUTO_PROVISIONING"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("requires SonarQube 10.5 or newer"),
+			},
+		},
+	})
+}
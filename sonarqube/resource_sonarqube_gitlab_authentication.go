@@ -0,0 +1,275 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// GitlabConfigurationV2 for unmarshalling response body of api/v2/dop-translation/gitlab-configurations
+type GitlabConfigurationV2 struct {
+	ID                 string   `json:"id"`
+	Enabled            bool     `json:"enabled"`
+	ApplicationID      string   `json:"applicationId"`
+	URL                string   `json:"url"`
+	SynchronizeGroups  bool     `json:"synchronizeGroups"`
+	AllowedGroups      []string `json:"allowedGroups"`
+	ProvisioningType   string   `json:"provisioningType"`
+	AllowUsersToSignUp bool     `json:"allowUsersToSignUp"`
+}
+
+// gitlabAuthenticationSettings maps the attributes to the settings used before api/v2/dop-translation.
+// The enabled flag is written last, once the rest of the configuration is in place.
+var gitlabAuthenticationSettings = []settingAttribute{
+	{attribute: "url", key: "sonar.auth.gitlab.url"},
+	{attribute: "application_id", key: "sonar.auth.gitlab.applicationId.secured", secured: true},
+	{attribute: "secret", key: "sonar.auth.gitlab.secret.secured", secured: true},
+	{attribute: "allowed_groups", key: "sonar.auth.gitlab.allowedGroups"},
+	{attribute: "synchronize_groups", key: "sonar.auth.gitlab.groupsSync"},
+	{attribute: "allow_users_to_sign_up", key: "sonar.auth.gitlab.allowUsersToSignUp"},
+	{attribute: "enabled", key: "sonar.auth.gitlab.enabled"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeGitlabAuthentication() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSonarqubeGitlabAuthenticationCreate,
+		Read:   resourceSonarqubeGitlabAuthenticationRead,
+		Update: resourceSonarqubeGitlabAuthenticationUpdate,
+		Delete: resourceSonarqubeGitlabAuthenticationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeGitlabAuthenticationImport,
+		},
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+				return validateProvisioningType(d, m, "provisioning_token")
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enable GitLab authentication",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "https://gitlab.com",
+				Description: "The url of the GitLab instance",
+			},
+			"application_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The application ID of the GitLab OAuth application",
+			},
+			"secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The secret of the GitLab OAuth application",
+			},
+			"allowed_groups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Only members of these groups are allowed to authenticate",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"synchronize_groups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Synchronize the GitLab groups of a user with the SonarQube groups of the same name",
+			},
+			"allow_users_to_sign_up": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Allow new users to authenticate. When false, only existing users can authenticate",
+			},
+			"provisioning_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "JIT",
+				Description: "How users and groups are provisioned: JIT (on sign in) or AUTO_PROVISIONING. AUTO_PROVISIONING requires SonarQube 10.5",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{"JIT", "AUTO_PROVISIONING"}, false),
+				),
+			},
+			"provisioning_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "A GitLab token with read access to the allowed groups. Required for automatic provisioning",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeGitlabAuthenticationCreate(d *schema.ResourceData, m interface{}) error {
+	if !dopTranslationV2ApiSupported(m) {
+		if err := setSettingAttributes(d, gitlabAuthenticationSettings, true, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeGitlabAuthenticationCreate: %+v", err)
+		}
+		d.SetId("gitlab")
+		return resourceSonarqubeGitlabAuthenticationRead(d, m)
+	}
+
+	resp, err := httpRequestJSONHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarqubeV2URL(m, "dop-translation/gitlab-configurations", nil),
+		gitlabConfigurationV2Body(d, true),
+		contentTypeJSON,
+		http.StatusOK,
+		"resourceSonarqubeGitlabAuthenticationCreate",
+	)
+	if err != nil {
+		return fmt.Errorf("error creating Sonarqube GitLab configuration: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	configurationResponse := GitlabConfigurationV2{}
+	err = json.NewDecoder(resp.Body).Decode(&configurationResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGitlabAuthenticationCreate: Failed to decode json into struct: %+v", err)
+	}
+	d.SetId(configurationResponse.ID)
+
+	return resourceSonarqubeGitlabAuthenticationRead(d, m)
+}
+
+func resourceSonarqubeGitlabAuthenticationRead(d *schema.ResourceData, m interface{}) error {
+	if !dopTranslationV2ApiSupported(m) {
+		if err := readSettingAttributes(d, gitlabAuthenticationSettings, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeGitlabAuthenticationRead: %+v", err)
+		}
+		d.Set("provisioning_type", "JIT")
+		return nil
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarqubeV2URL(m, "dop-translation/gitlab-configurations/"+url.PathEscape(d.Id()), nil),
+		http.StatusOK,
+		"resourceSonarqubeGitlabAuthenticationRead",
+	)
+	if resp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading Sonarqube GitLab configuration: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	configuration := GitlabConfigurationV2{}
+	err = json.NewDecoder(resp.Body).Decode(&configuration)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGitlabAuthenticationRead: Failed to decode json into struct: %+v", err)
+	}
+
+	// The secret and the provisioning token are never returned by the api
+	d.Set("enabled", configuration.Enabled)
+	d.Set("url", configuration.URL)
+	d.Set("application_id", configuration.ApplicationID)
+	d.Set("allowed_groups", configuration.AllowedGroups)
+	d.Set("synchronize_groups", configuration.SynchronizeGroups)
+	d.Set("allow_users_to_sign_up", configuration.AllowUsersToSignUp)
+	d.Set("provisioning_type", configuration.ProvisioningType)
+	return nil
+}
+
+func resourceSonarqubeGitlabAuthenticationUpdate(d *schema.ResourceData, m interface{}) error {
+	if !dopTranslationV2ApiSupported(m) {
+		if err := setSettingAttributes(d, gitlabAuthenticationSettings, false, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeGitlabAuthenticationUpdate: %+v", err)
+		}
+		return resourceSonarqubeGitlabAuthenticationRead(d, m)
+	}
+
+	resp, err := httpRequestJSONHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"PATCH",
+		sonarqubeV2URL(m, "dop-translation/gitlab-configurations/"+url.PathEscape(d.Id()), nil),
+		gitlabConfigurationV2Body(d, false),
+		contentTypeJSONMerge,
+		http.StatusOK,
+		"resourceSonarqubeGitlabAuthenticationUpdate",
+	)
+	if err != nil {
+		return fmt.Errorf("error updating Sonarqube GitLab configuration: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return resourceSonarqubeGitlabAuthenticationRead(d, m)
+}
+
+func resourceSonarqubeGitlabAuthenticationDelete(d *schema.ResourceData, m interface{}) error {
+	if !dopTranslationV2ApiSupported(m) {
+		if err := resetSettingAttributes(gitlabAuthenticationSettings, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeGitlabAuthenticationDelete: %+v", err)
+		}
+		return nil
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"DELETE",
+		sonarqubeV2URL(m, "dop-translation/gitlab-configurations/"+url.PathEscape(d.Id()), nil),
+		http.StatusNoContent,
+		"resourceSonarqubeGitlabAuthenticationDelete",
+	)
+	if resp.StatusCode == http.StatusNotFound {
+		// The configuration has already been removed
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error deleting Sonarqube GitLab configuration: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func resourceSonarqubeGitlabAuthenticationImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSonarqubeGitlabAuthenticationRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// gitlabConfigurationV2Body returns the request body for api/v2/dop-translation/gitlab-configurations.
+// Secrets are only sent on create or when they changed.
+func gitlabConfigurationV2Body(d *schema.ResourceData, create bool) map[string]interface{} {
+	configuration := map[string]interface{}{
+		"enabled":            d.Get("enabled").(bool),
+		"url":                d.Get("url").(string),
+		"applicationId":      d.Get("application_id").(string),
+		"allowedGroups":      expandStringSet(d.Get("allowed_groups").(*schema.Set)),
+		"synchronizeGroups":  d.Get("synchronize_groups").(bool),
+		"allowUsersToSignUp": d.Get("allow_users_to_sign_up").(bool),
+		"provisioningType":   d.Get("provisioning_type").(string),
+	}
+	if create || d.HasChange("secret") {
+		configuration["secret"] = d.Get("secret").(string)
+	}
+	if provisioningToken := d.Get("provisioning_token").(string); provisioningToken != "" && (create || d.HasChange("provisioning_token")) {
+		configuration["provisioningToken"] = provisioningToken
+	}
+	return configuration
+}
@@ -0,0 +1,164 @@
+package sonarqube
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_gitlab_authentication", &resource.Sweeper{
+		Name: "sonarqube_gitlab_authentication",
+		F:    testSweepSonarqubeGitlabAuthenticationSweeper,
+	})
+}
+
+func testSweepSonarqubeGitlabAuthenticationSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeGitlabAuthenticationConfig(rnd string, name string, allowSignUp bool) string {
+	return fmt.Sprintf(`
+        resource "sonarqube_gitlab_authentication" "%[1]s" {
+            url                    = "https://gitlab.example.com"
+            application_id         = "%[2]s-application"
+            secret                 = "secret"
+            allowed_groups         = ["%[2]s"]
+            allow_users_to_sign_up = %[3]t
+        }`, rnd, name, allowSignUp)
+}
+
+func TestAccSonarqubeGitlabAuthentication(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_gitlab_authentication." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGitlabAuthenticationConfig(rnd, "testAccSonarqubeGitlabAuthentication", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+					resource.TestCheckResourceAttr(name, "allow_users_to_sign_up", "true"),
+					resource.TestCheckResourceAttr(name, "allowed_groups.#", "1"),
+					resource.TestCheckResourceAttr(name, "provisioning_type", "JIT"),
+				),
+			},
+			{
+				Config: testAccSonarqubeGitlabAuthenticationConfig(rnd, "testAccSonarqubeGitlabAuthentication", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "allow_users_to_sign_up", "false"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The secrets are not returned by SonarQube, the application id only by api/v2/dop-translation
+				ImportStateVerifyIgnore: []string{"application_id", "secret", "provisioning_token"},
+			},
+		},
+	})
+}
+
+func testAccSonarqubeGitlabAuthenticationProvisioningConfig(rnd string, name string, provisioningType string) string {
+	return fmt.Sprintf(`
+        resource "sonarqube_gitlab_authentication" "%[1]s" {
+            url                = "https://gitlab.example.com"
+            application_id     = "%[2]s-application"
+            secret             = "secret"
+            provisioning_token = "token"
+            allowed_groups     = ["%[2]s"]
+            provisioning_type  = "%[3]s"
+        }`, rnd, name, provisioningType)
+}
+
+func TestAccSonarqubeGitlabAuthenticationV2(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_gitlab_authentication." + rnd
+	configurationID := ""
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckDopTranslationV2Api(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGitlabAuthenticationProvisioningConfig(rnd, "testAccSonarqubeGitlabAuthenticationV2", "JIT"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "provisioning_type", "JIT"),
+					resource.TestCheckResourceAttr(name, "application_id", "testAccSonarqubeGitlabAuthenticationV2-application"),
+					func(s *terraform.State) error {
+						configurationID = s.RootModule().Resources[name].Primary.ID
+						if configurationID == "gitlab" {
+							return fmt.Errorf("configuration was created with the settings instead of api/v2/dop-translation")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccSonarqubeGitlabAuthenticationProvisioningConfig(rnd, "testAccSonarqubeGitlabAuthenticationV2", "AUTO_PROVISIONING"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "provisioning_type", "AUTO_PROVISIONING"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret", "provisioning_token"},
+			},
+			{
+				// A configuration deleted outside of terraform is created again, and deleting it again is a no-op
+				PreConfig: func() {
+					d := schema.TestResourceDataRaw(t, resourceSonarqubeGitlabAuthentication().Schema, map[string]interface{}{})
+					d.SetId(configurationID)
+					for i := 0; i < 2; i++ {
+						if err := resourceSonarqubeGitlabAuthenticationDelete(d, testAccProvider.Meta()); err != nil {
+							t.Fatalf("deleting the GitLab configuration failed: %+v", err)
+						}
+					}
+				},
+				Config: testAccSonarqubeGitlabAuthenticationProvisioningConfig(rnd, "testAccSonarqubeGitlabAuthenticationV2", "AUTO_PROVISIONING"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "provisioning_type", "AUTO_PROVISIONING"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources[name].Primary.ID == configurationID {
+							return fmt.Errorf("configuration was not created again")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccSonarqubeGitlabAuthenticationAutoProvisioningUnsupported(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if dopTranslationV2ApiSupported(testAccProvider.Meta()) {
+				t.Skipf("Skipping test of AUTO_PROVISIONING on SonarQube older than 10.5")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeGitlabAuthenticationProvisioningConfig(rnd, "testAccSonarqubeGitlabAuthenticationAutoProvisioningUnsupported", "AUTO_PROVISIONING"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("requires SonarQube 10.5 or newer"),
+			},
+		},
+	})
+}
@@ -29,6 +29,7 @@ type Group struct {
 	Description  string   `json:"description,omitempty"`
 	MembersCount int      `json:"membersCount,omitempty"`
 	IsDefault    bool     `json:"default,omitempty"`
+	Managed      bool     `json:"managed,omitempty"`
 	Permissions  []string `json:"permissions,omitempty"`
 }
 
@@ -58,6 +59,10 @@ func resourceSonarqubeGroup() *schema.Resource {
 }
 
 func resourceSonarqubeGroupCreate(d *schema.ResourceData, m interface{}) error {
+	if groupsV2ApiSupported(m) {
+		return resourceSonarqubeGroupCreateV2(d, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/create"
 	sonarQubeURL.RawQuery = url.Values{
@@ -89,6 +94,10 @@ func resourceSonarqubeGroupCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeGroupRead(d *schema.ResourceData, m interface{}) error {
+	if groupsV2ApiSupported(m) {
+		return resourceSonarqubeGroupReadV2(d, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/search"
 	sonarQubeURL.RawQuery = url.Values{
@@ -145,6 +154,10 @@ func resourceSonarqubeGroupRead(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeGroupUpdate(d *schema.ResourceData, m interface{}) error {
+	if groupsV2ApiSupported(m) {
+		return resourceSonarqubeGroupUpdateV2(d, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/update"
 
@@ -181,6 +194,10 @@ func resourceSonarqubeGroupUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeGroupDelete(d *schema.ResourceData, m interface{}) error {
+	if groupsV2ApiSupported(m) {
+		return resourceSonarqubeGroupDeleteV2(d, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/delete"
 
@@ -209,3 +226,123 @@ func resourceSonarqubeGroupImport(d *schema.ResourceData, m interface{}) ([]*sch
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+func resourceSonarqubeGroupCreateV2(d *schema.ResourceData, m interface{}) error {
+	group := map[string]interface{}{
+		"name": d.Get("name").(string),
+	}
+	if description, ok := d.GetOk("description"); ok {
+		group["description"] = description.(string)
+	}
+
+	resp, err := httpRequestJSONHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarqubeV2URL(m, "authorizations/groups", nil),
+		group,
+		contentTypeJSON,
+		http.StatusOK,
+		"resourceSonarqubeGroupCreateV2",
+	)
+	if err != nil {
+		return fmt.Errorf("error creating Sonarqube group: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	groupResponse := GroupV2{}
+	err = json.NewDecoder(resp.Body).Decode(&groupResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupCreateV2: Failed to decode json into struct: %+v", err)
+	}
+	d.SetId(groupResponse.ID)
+
+	return resourceSonarqubeGroupRead(d, m)
+}
+
+func resourceSonarqubeGroupReadV2(d *schema.ResourceData, m interface{}) error {
+	group, err := findGroupV2(d.Id(), "", m)
+	if err == nil && group == nil && d.Get("name").(string) == "" {
+		// Groups can also be imported by their name
+		group, err = findGroupV2("", d.Id(), m)
+	}
+	if err != nil {
+		return fmt.Errorf("error reading Sonarqube group: %+v", err)
+	}
+	if group == nil {
+		// Group not found
+		d.SetId("")
+		return nil
+	}
+
+	// Imported groups have no name yet and get the v2 id, the id of existing state is kept
+	if d.Get("name").(string) == "" {
+		d.SetId(group.ID)
+	}
+	d.Set("name", group.Name)
+	d.Set("description", group.Description)
+	return nil
+}
+
+func resourceSonarqubeGroupUpdateV2(d *schema.ResourceData, m interface{}) error {
+	oldName, newName := d.GetChange("name")
+	group, err := findGroupV2(d.Id(), "", m)
+	if err != nil {
+		return fmt.Errorf("error updating Sonarqube group: %+v", err)
+	}
+	if group == nil {
+		return fmt.Errorf("resourceSonarqubeGroupUpdateV2: Failed to find group: %+v", d.Id())
+	}
+
+	patch := map[string]interface{}{}
+	if newName != oldName {
+		patch["name"] = newName.(string)
+	}
+	// A null value removes the description in a merge patch
+	if description, ok := d.GetOk("description"); ok {
+		patch["description"] = description.(string)
+	} else {
+		patch["description"] = nil
+	}
+
+	resp, err := httpRequestJSONHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"PATCH",
+		sonarqubeV2URL(m, "authorizations/groups/"+url.PathEscape(group.ID), nil),
+		patch,
+		contentTypeJSONMerge,
+		http.StatusOK,
+		"resourceSonarqubeGroupUpdateV2",
+	)
+	if err != nil {
+		return fmt.Errorf("error updating Sonarqube group: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return resourceSonarqubeGroupRead(d, m)
+}
+
+func resourceSonarqubeGroupDeleteV2(d *schema.ResourceData, m interface{}) error {
+	group, err := findGroupV2(d.Id(), "", m)
+	if err != nil {
+		return fmt.Errorf("error deleting Sonarqube group: %+v", err)
+	}
+	if group == nil {
+		// The group does not exist anymore
+		return nil
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"DELETE",
+		sonarqubeV2URL(m, "authorizations/groups/"+url.PathEscape(group.ID), nil),
+		http.StatusNoContent,
+		"resourceSonarqubeGroupDeleteV2",
+	)
+	if err != nil {
+		return fmt.Errorf("error deleting Sonarqube group: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
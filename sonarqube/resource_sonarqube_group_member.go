@@ -50,13 +50,6 @@ func resourceSonarqubeGroupMember() *schema.Resource {
 }
 
 func resourceSonarqubeGroupMemberCreate(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/add_user"
-	sonarQubeURL.RawQuery = url.Values{
-		"name":  []string{d.Get("name").(string)},
-		"login": []string{d.Get("login_name").(string)},
-	}.Encode()
-
 	groupMembershipId := createGroupMembershipId(d.Get("name").(string), d.Get("login_name").(string))
 
 	// We need to check if a user is already a member in advance because SQ does not report this conflict in the add_user API call:
@@ -65,17 +58,10 @@ func resourceSonarqubeGroupMemberCreate(d *schema.ResourceData, m interface{}) e
 		return fmt.Errorf("resourceSonarqubeGroupMemberCreate: Group membership already exists: %+v", groupMembershipId)
 	}
 
-	resp, err := httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"POST",
-		sonarQubeURL.String(),
-		http.StatusNoContent,
-		"resourceSonarqubeGroupMemberCreate",
-	)
+	err := setGroupMember(d.Get("name").(string), d.Get("login_name").(string), true, m)
 	if err != nil {
 		return fmt.Errorf("error adding user '%s' to Sonarqube group '%s': %w", d.Get("login_name").(string), d.Get("name").(string), err)
 	}
-	defer resp.Body.Close()
 
 	d.SetId(groupMembershipId)
 
@@ -83,6 +69,18 @@ func resourceSonarqubeGroupMemberCreate(d *schema.ResourceData, m interface{}) e
 }
 
 func resourceSonarqubeGroupMemberRead(d *schema.ResourceData, m interface{}) error {
+	if groupsV2ApiSupported(m) {
+		exists, err := groupMemberExistsV2(d.Get("name").(string), d.Get("login_name").(string), m)
+		if err != nil {
+			return fmt.Errorf("error reading Sonarqube members of group '%s': %w", d.Get("name").(string), err)
+		}
+		if !exists {
+			// Group member not found
+			d.SetId("")
+		}
+		return nil
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/users"
 	sonarQubeURL.RawQuery = url.Values{
@@ -130,25 +128,10 @@ func resourceSonarqubeGroupMemberRead(d *schema.ResourceData, m interface{}) err
 }
 
 func resourceSonarqubeGroupMemberDelete(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/remove_user"
-
-	sonarQubeURL.RawQuery = url.Values{
-		"name":  []string{d.Get("name").(string)},
-		"login": []string{d.Get("login_name").(string)},
-	}.Encode()
-
-	resp, err := httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"POST",
-		sonarQubeURL.String(),
-		http.StatusNoContent,
-		"resourceSonarqubeGroupMemberDelete",
-	)
+	err := setGroupMember(d.Get("name").(string), d.Get("login_name").(string), false, m)
 	if err != nil {
 		return fmt.Errorf("error deleting Sonarqube member '%s' from group '%s': %w", d.Get("login_name").(string), d.Get("name").(string), err)
 	}
-	defer resp.Body.Close()
 
 	return nil
 }
@@ -171,6 +154,10 @@ func resourceSonarqubeGroupMemberImport(d *schema.ResourceData, m interface{}) (
 }
 
 func checkGroupMemberExists(groupName string, loginName string, m interface{}) (bool, error) {
+	if groupsV2ApiSupported(m) {
+		return groupMemberExistsV2(groupName, loginName, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/users"
 	sonarQubeURL.RawQuery = url.Values{
@@ -0,0 +1,213 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeGroupMembers() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSonarqubeGroupMembersCreate,
+		Read:   resourceSonarqubeGroupMembersRead,
+		Update: resourceSonarqubeGroupMembersUpdate,
+		Delete: resourceSonarqubeGroupMembersDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeGroupMembersImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the group",
+			},
+			"members": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The logins of all members of the group",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceSonarqubeGroupMembersCreate(d *schema.ResourceData, m interface{}) error {
+	groupName := d.Get("name").(string)
+
+	// Start from the members that already exist so the configured set becomes authoritative
+	currentMembers, err := readGroupMembersFromApi(groupName, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupMembersCreate: Failed to read members of group '%s': %+v", groupName, err)
+	}
+
+	err = synchronizeGroupMembers(groupName, currentMembers, expandStringSet(d.Get("members").(*schema.Set)), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupMembersCreate: %+v", err)
+	}
+
+	d.SetId(groupName)
+	return resourceSonarqubeGroupMembersRead(d, m)
+}
+
+func resourceSonarqubeGroupMembersRead(d *schema.ResourceData, m interface{}) error {
+	members, err := readGroupMembersFromApi(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupMembersRead: Failed to read members of group '%s': %+v", d.Id(), err)
+	}
+	if members == nil {
+		// The group does not exist anymore
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", d.Id())
+	d.Set("members", members)
+	return nil
+}
+
+func resourceSonarqubeGroupMembersUpdate(d *schema.ResourceData, m interface{}) error {
+	oldMembers, newMembers := d.GetChange("members")
+	err := synchronizeGroupMembers(d.Id(), expandStringSet(oldMembers.(*schema.Set)), expandStringSet(newMembers.(*schema.Set)), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupMembersUpdate: %+v", err)
+	}
+
+	return resourceSonarqubeGroupMembersRead(d, m)
+}
+
+func resourceSonarqubeGroupMembersDelete(d *schema.ResourceData, m interface{}) error {
+	err := synchronizeGroupMembers(d.Id(), expandStringSet(d.Get("members").(*schema.Set)), []string{}, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupMembersDelete: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceSonarqubeGroupMembersImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	groupName := d.Id()
+	if err := resourceSonarqubeGroupMembersRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeGroupMembersImport: Group '%s' does not exist", groupName)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// readGroupMembersFromApi returns the logins of all members of the group, or nil if the group does not exist
+func readGroupMembersFromApi(groupName string, m interface{}) ([]string, error) {
+	if groupsV2ApiSupported(m) {
+		return readGroupMembersV2(groupName, m)
+	}
+
+	members := make([]string, 0)
+
+	page := int64(1)
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/users"
+		sonarQubeURL.RawQuery = url.Values{
+			"name":     []string{groupName},
+			"selected": []string{"selected"},
+			"p":        []string{strconv.FormatInt(page, 10)},
+			"ps":       []string{"500"},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"readGroupMembersFromApi",
+		)
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Decode response into struct
+		groupMembersResponse := GetGroupMembersResponse{}
+		err = json.NewDecoder(resp.Body).Decode(&groupMembersResponse)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("readGroupMembersFromApi: Failed to decode json into struct: %+v", err)
+		}
+
+		for _, value := range groupMembersResponse.Members {
+			members = append(members, value.LoginName)
+		}
+
+		// Stop once the last page has been read
+		if len(groupMembersResponse.Members) == 0 || groupMembersResponse.Paging.PageIndex*groupMembersResponse.Paging.PageSize >= groupMembersResponse.Paging.Total {
+			break
+		}
+		page++
+	}
+
+	return members, nil
+}
+
+// synchronizeGroupMembers adds and removes users so that the group matches the desired list of logins
+func synchronizeGroupMembers(groupName string, current []string, desired []string, m interface{}) error {
+	for _, login := range desired {
+		if !containsStringFold(current, login) {
+			if err := setGroupMember(groupName, login, true, m); err != nil {
+				return fmt.Errorf("Failed to add user '%s' to group '%s': %+v", login, groupName, err)
+			}
+		}
+	}
+
+	for _, login := range current {
+		if !containsStringFold(desired, login) {
+			if err := setGroupMember(groupName, login, false, m); err != nil {
+				return fmt.Errorf("Failed to remove user '%s' from group '%s': %+v", login, groupName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func setGroupMember(groupName string, login string, add bool, m interface{}) error {
+	if groupsV2ApiSupported(m) {
+		return setGroupMemberV2(groupName, login, add, m)
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	action := "remove_user"
+	if add {
+		action = "add_user"
+	}
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/" + action
+	sonarQubeURL.RawQuery = url.Values{
+		"name":  []string{groupName},
+		"login": []string{login},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"setGroupMember",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
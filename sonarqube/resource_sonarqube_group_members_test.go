@@ -0,0 +1,143 @@
+package sonarqube
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_group_members", &resource.Sweeper{
+		Name: "sonarqube_group_members",
+		F:    testSweepSonarqubeGroupMembersSweeper,
+	})
+}
+
+func testSweepSonarqubeGroupMembersSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeGroupMembersConfig(rnd string, groupName string, members []string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s_one" {
+			login_name = "%[1]s-one"
+			name       = "Test User One"
+			password   = "secret-sauce37!"
+		}
+
+		resource "sonarqube_user" "%[1]s_two" {
+			login_name = "%[1]s-two"
+			name       = "Test User Two"
+			password   = "secret-sauce37!"
+		}
+
+		resource "sonarqube_group" "%[1]s" {
+			name = "%[2]s"
+		}
+
+		resource "sonarqube_group_members" "%[1]s" {
+			name    = sonarqube_group.%[1]s.name
+			members = [%[3]s]
+
+			depends_on = [sonarqube_user.%[1]s_one, sonarqube_user.%[1]s_two]
+		}
+		`, rnd, groupName, strings.Join(members, ", "))
+}
+
+func TestAccSonarqubeGroupMembersBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_group_members." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGroupMembersConfig(rnd, "testAccSonarqubeGroupMembers", []string{`"` + rnd + `-one"`, `"` + rnd + `-two"`}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeGroupMembers"),
+					resource.TestCheckResourceAttr(name, "members.#", "2"),
+				),
+			},
+			{
+				Config: testAccSonarqubeGroupMembersConfig(rnd, "testAccSonarqubeGroupMembers", []string{`"` + rnd + `-two"`}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "members.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "members.*", rnd+"-two"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "testAccSonarqubeGroupMembers",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSonarqubeGroupMembershipV2Config(rnd string, groupName string, members []string) string {
+	return testAccSonarqubeGroupMembersConfig(rnd, groupName, members) + fmt.Sprintf(`
+		resource "sonarqube_group" "%[1]s_single" {
+			name = "%[2]s-single"
+		}
+
+		resource "sonarqube_group_member" "%[1]s" {
+			name       = sonarqube_group.%[1]s_single.name
+			login_name = sonarqube_user.%[1]s_one.login_name
+		}
+
+		data "sonarqube_group" "%[1]s" {
+			name            = sonarqube_group_members.%[1]s.name
+			include_members = true
+		}
+		`, rnd, groupName)
+}
+
+func TestAccSonarqubeGroupMembershipV2(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_group_members." + rnd
+	memberName := "sonarqube_group_member." + rnd
+	dataSourceName := "data.sonarqube_group." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckGroupsV2Api(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGroupMembershipV2Config(rnd, "testAccSonarqubeGroupMembershipV2", []string{`"` + rnd + `-one"`, `"` + rnd + `-two"`}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "members.#", "2"),
+					resource.TestCheckResourceAttr(memberName, "id", "testAccSonarqubeGroupMembershipV2-single["+rnd+"-one]"),
+					resource.TestCheckResourceAttr(dataSourceName, "members_count", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "members.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "managed", "false"),
+				),
+			},
+			{
+				Config: testAccSonarqubeGroupMembershipV2Config(rnd, "testAccSonarqubeGroupMembershipV2", []string{`"` + rnd + `-two"`}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "members.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "members.*", rnd+"-two"),
+					resource.TestCheckResourceAttr(dataSourceName, "members_count", "1"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "testAccSonarqubeGroupMembershipV2",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      memberName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -2,9 +2,13 @@ package sonarqube
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func init() {
@@ -108,3 +112,145 @@ func TestAccSonarqubeGroupImport(t *testing.T) {
 		},
 	})
 }
+
+func testAccPreCheckGroupsV2Api(t *testing.T) {
+	if !groupsV2ApiSupported(testAccProvider.Meta()) {
+		t.Skipf("Skipping test of api/v2/authorizations, which requires SonarQube 10.5")
+	}
+}
+
+func TestAccSonarqubeGroupV2(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_group." + rnd
+	groupName := "testAccSonarqubeGroupV2" + rnd
+	updatedGroupName := "testAccSonarqubeGroupV2Updated" + rnd
+	groupID := ""
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckGroupsV2Api(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGroupBasicConfig(rnd, groupName, "group description"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", groupName),
+					resource.TestCheckResourceAttr(name, "description", "group description"),
+					func(s *terraform.State) error {
+						groupID = s.RootModule().Resources[name].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccSonarqubeGroupBasicConfig(rnd, updatedGroupName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", updatedGroupName),
+					resource.TestCheckResourceAttr(name, "description", ""),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources[name].Primary.ID != groupID {
+							return fmt.Errorf("group was recreated instead of updated in place")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// A group renamed outside of terraform is found by its id and renamed back
+				PreConfig: func() {
+					if err := testAccRenameSonarqubeGroupV2(groupID, updatedGroupName+"Renamed"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccSonarqubeGroupBasicConfig(rnd, updatedGroupName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", updatedGroupName),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources[name].Primary.ID != groupID {
+							return fmt.Errorf("group was recreated instead of renamed back")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// Import by name
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     updatedGroupName,
+				ImportStateVerify: true,
+			},
+			{
+				// A group deleted outside of terraform is created again, and deleting it again is a no-op
+				PreConfig: func() {
+					if err := testAccDeleteSonarqubeGroupV2(updatedGroupName); err != nil {
+						t.Fatal(err)
+					}
+					d := schema.TestResourceDataRaw(t, resourceSonarqubeGroup().Schema, map[string]interface{}{
+						"name": updatedGroupName,
+					})
+					d.SetId(groupID)
+					if err := resourceSonarqubeGroupDelete(d, testAccProvider.Meta()); err != nil {
+						t.Fatalf("deleting a missing group failed: %+v", err)
+					}
+				},
+				Config: testAccSonarqubeGroupBasicConfig(rnd, updatedGroupName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", updatedGroupName),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources[name].Primary.ID == groupID {
+							return fmt.Errorf("group was not created again")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// testAccRenameSonarqubeGroupV2 renames a group outside of terraform
+func testAccRenameSonarqubeGroupV2(groupID string, groupName string) error {
+	m := testAccProvider.Meta()
+	resp, err := httpRequestJSONHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"PATCH",
+		sonarqubeV2URL(m, "authorizations/groups/"+url.PathEscape(groupID), nil),
+		map[string]interface{}{"name": groupName},
+		contentTypeJSONMerge,
+		http.StatusOK,
+		"testAccRenameSonarqubeGroupV2",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
+// testAccDeleteSonarqubeGroupV2 deletes a group outside of terraform
+func testAccDeleteSonarqubeGroupV2(groupName string) error {
+	m := testAccProvider.Meta()
+	group, err := findGroupV2("", groupName, m)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("group '%s' does not exist", groupName)
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"DELETE",
+		sonarqubeV2URL(m, "authorizations/groups/"+url.PathEscape(group.ID), nil),
+		http.StatusNoContent,
+		"testAccDeleteSonarqubeGroupV2",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,150 @@
+package sonarqube
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeQualityGatePermissions() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSonarqubeQualityGatePermissionsCreate,
+		Read:   resourceSonarqubeQualityGatePermissionsRead,
+		Update: resourceSonarqubeQualityGatePermissionsUpdate,
+		Delete: resourceSonarqubeQualityGatePermissionsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeQualityGatePermissionsImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"gatename": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Quality Gate",
+			},
+			"users": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The logins of the users allowed to edit the Quality Gate",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"groups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The names of the groups allowed to edit the Quality Gate",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceSonarqubeQualityGatePermissionsCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkGatePermissionFeatureSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	gateName := d.Get("gatename").(string)
+
+	// Start from the permissions that already exist on the gate so the configured sets become authoritative
+	target := qualityGateEditPermissionsTarget(gateName)
+	currentUsers, currentGroups, err := readEditPermissionsFromApi(target, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGatePermissionsCreate: Failed to read permissions of quality gate '%s': %+v", gateName, err)
+	}
+	if currentUsers == nil {
+		return fmt.Errorf("resourceSonarqubeQualityGatePermissionsCreate: Failed to find quality gate '%s'", gateName)
+	}
+
+	err = synchronizeEditPermissions(target, "user", currentUsers, expandStringSet(d.Get("users").(*schema.Set)), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGatePermissionsCreate: %+v", err)
+	}
+	err = synchronizeEditPermissions(target, "group", currentGroups, expandStringSet(d.Get("groups").(*schema.Set)), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGatePermissionsCreate: %+v", err)
+	}
+
+	d.SetId(gateName)
+	return resourceSonarqubeQualityGatePermissionsRead(d, m)
+}
+
+func resourceSonarqubeQualityGatePermissionsRead(d *schema.ResourceData, m interface{}) error {
+	if err := checkGatePermissionFeatureSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	users, groups, err := readEditPermissionsFromApi(qualityGateEditPermissionsTarget(d.Id()), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGatePermissionsRead: Failed to read permissions of quality gate '%s': %+v", d.Id(), err)
+	}
+	if users == nil {
+		log.Printf("[WARN] Quality gate '%s' not found, removing permissions from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("gatename", d.Id())
+	d.Set("users", users)
+	d.Set("groups", groups)
+	return nil
+}
+
+func resourceSonarqubeQualityGatePermissionsUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := checkGatePermissionFeatureSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	target := qualityGateEditPermissionsTarget(d.Id())
+
+	if d.HasChange("users") {
+		oldUsers, newUsers := d.GetChange("users")
+		err := synchronizeEditPermissions(target, "user", expandStringSet(oldUsers.(*schema.Set)), expandStringSet(newUsers.(*schema.Set)), m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityGatePermissionsUpdate: %+v", err)
+		}
+	}
+
+	if d.HasChange("groups") {
+		oldGroups, newGroups := d.GetChange("groups")
+		err := synchronizeEditPermissions(target, "group", expandStringSet(oldGroups.(*schema.Set)), expandStringSet(newGroups.(*schema.Set)), m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityGatePermissionsUpdate: %+v", err)
+		}
+	}
+
+	return resourceSonarqubeQualityGatePermissionsRead(d, m)
+}
+
+func resourceSonarqubeQualityGatePermissionsDelete(d *schema.ResourceData, m interface{}) error {
+	if err := checkGatePermissionFeatureSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	target := qualityGateEditPermissionsTarget(d.Id())
+
+	err := synchronizeEditPermissions(target, "user", expandStringSet(d.Get("users").(*schema.Set)), []string{}, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGatePermissionsDelete: %+v", err)
+	}
+	err = synchronizeEditPermissions(target, "group", expandStringSet(d.Get("groups").(*schema.Set)), []string{}, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGatePermissionsDelete: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceSonarqubeQualityGatePermissionsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSonarqubeQualityGatePermissionsRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
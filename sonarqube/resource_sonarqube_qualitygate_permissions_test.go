@@ -0,0 +1,101 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_qualitygate_permissions", &resource.Sweeper{
+		Name: "sonarqube_qualitygate_permissions",
+		F:    testSweepSonarqubeQualitygatePermissionsSweeper,
+	})
+}
+
+// TODO: implement sweeper to clean up projects: https://www.terraform.io/docs/extend/testing/acceptance-tests/sweepers.html
+func testSweepSonarqubeQualitygatePermissionsSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeQualitygatePermissionsConfig(rnd string, name string, users string, groups string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name = "%[2]s"
+			name       = "%[2]s"
+			password   = "secret-sauce37!"
+		}
+
+		resource "sonarqube_group" "%[1]s" {
+			name        = "%[2]s"
+			description = "foo"
+		}
+
+		resource "sonarqube_qualitygate" "%[1]s" {
+			name = "%[2]s"
+
+			condition {
+				metric    = "new_coverage"
+				op        = "LT"
+				threshold = "30"
+			}
+		}
+
+		resource "sonarqube_qualitygate_permissions" "%[1]s" {
+			gatename = sonarqube_qualitygate.%[1]s.name
+			users    = %[3]s
+			groups   = %[4]s
+		}`, rnd, name, users, groups)
+}
+
+func TestAccSonarqubeQualitygatePermissions(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualitygate_permissions." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccPreCheckQualityGatePermissionFeature(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualitygatePermissionsConfig(rnd, "testAccQualitygatePermissions", "[sonarqube_user."+rnd+".login_name]", "[sonarqube_group."+rnd+".name]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "gatename", "testAccQualitygatePermissions"),
+					resource.TestCheckResourceAttr(name, "users.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "users.*", "testAccQualitygatePermissions"),
+					resource.TestCheckResourceAttr(name, "groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "groups.*", "testAccQualitygatePermissions"),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualitygatePermissionsConfig(rnd, "testAccQualitygatePermissions", "[]", "[sonarqube_group."+rnd+".name]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "users.#", "0"),
+					resource.TestCheckResourceAttr(name, "groups.#", "1"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "testAccQualitygatePermissions",
+				ImportStateVerify: true,
+			},
+			{
+				// Permissions of a gate deleted outside of terraform are removed from the state
+				PreConfig: func() {
+					d := schema.TestResourceDataRaw(t, resourceSonarqubeQualityGate().Schema, map[string]interface{}{
+						"name": "testAccQualitygatePermissions",
+					})
+					d.SetId("testAccQualitygatePermissions")
+					if err := resourceSonarqubeQualityGateDelete(d, testAccProvider.Meta()); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccSonarqubeQualitygatePermissionsConfig(rnd, "testAccQualitygatePermissions", "[]", "[sonarqube_group."+rnd+".name]"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
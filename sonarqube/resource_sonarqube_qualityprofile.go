@@ -1,12 +1,15 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
 	"net/url"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -43,6 +46,8 @@ type GetQualityProfile struct {
 	ActiveRuleCount           int                      `json:"activeRuleCount"`
 	ActiveDeprecatedRuleCount int                      `json:"activeDeprecatedRuleCount"`
 	IsDefault                 bool                     `json:"isDefault"`
+	ParentKey                 string                   `json:"parentKey,omitempty"`
+	ParentName                string                   `json:"parentName,omitempty"`
 	RuleUpdatedAt             string                   `json:"ruleUpdatedAt"`
 	LastUsed                  string                   `json:"lastUsed"`
 	Actions                   GetQualityProfileActions `json:"actions"`
@@ -62,17 +67,22 @@ func resourceSonarqubeQualityProfile() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceSonarqubeQualityProfileCreate,
 		Read:   resourceSonarqubeQualityProfileRead,
+		Update: resourceSonarqubeQualityProfileUpdate,
 		Delete: resourceSonarqubeQualityProfileDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeQualityProfileImport,
 		},
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+				return validateLanguage(d, m)
+			},
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "Quality profile name",
 				ValidateDiagFunc: validation.ToDiagFunc(
 					validation.StringLenBetween(0, 100),
@@ -94,12 +104,14 @@ func resourceSonarqubeQualityProfile() *schema.Resource {
 				Optional:    true,
 				Description: "Is the default profile",
 				Default:     false,
-				ForceNew:    true,
 			},
 			"parent": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the parent quality profile",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
 			},
 		},
 	}
@@ -149,40 +161,48 @@ func resourceSonarqubeQualityProfileCreate(d *schema.ResourceData, m interface{}
 }
 
 func resourceSonarqubeQualityProfileRead(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/search"
-
-	resp, err := httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"GET",
-		sonarQubeURL.String(),
-		http.StatusOK,
-		"resourceSonarqubeQualityProfileRead",
-	)
+	qualityProfile, err := getQualityProfileByKey(d.Id(), m)
 	if err != nil {
-		return err
+		return fmt.Errorf("resourceSonarqubeQualityProfileRead: %+v", err)
+	}
+	if qualityProfile == nil {
+		log.Printf("[WARN] Quality profile '%s' not found, removing it from state", d.Id())
+		d.SetId("")
+		return nil
 	}
-	defer resp.Body.Close()
 
-	// Decode response into struct
-	getQualityProfileResponse := GetQualityProfileList{}
-	err = json.NewDecoder(resp.Body).Decode(&getQualityProfileResponse)
-	if err != nil {
-		return fmt.Errorf("resourceSonarqubeQualityProfileRead: Failed to decode json into struct: %+v", err)
+	d.SetId(qualityProfile.Key)
+	d.Set("name", qualityProfile.Name)
+	d.Set("language", qualityProfile.Language)
+	d.Set("key", qualityProfile.Key)
+	d.Set("is_default", qualityProfile.IsDefault)
+	d.Set("parent", qualityProfile.ParentName)
+	return nil
+}
+
+func resourceSonarqubeQualityProfileUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("name") {
+		err := renameQualityProfile(d, m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileUpdate: Failed to rename quality profile: %+v", err)
+		}
 	}
 
-	for _, value := range getQualityProfileResponse.Profiles {
-		if d.Id() == value.Key {
-			d.SetId(value.Key)
-			d.Set("name", value.Name)
-			d.Set("language", value.Language)
-			d.Set("key", value.Key)
-			d.Set("is_default", value.IsDefault)
-			return nil
+	if d.HasChange("parent") {
+		err := setParentQualityProfile(d, m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileUpdate: Failed to change the parent of quality profile: %+v", err)
+		}
+	}
+
+	if d.HasChange("is_default") {
+		err := setDefaultQualityProfile(d, m, d.Get("is_default").(bool))
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileUpdate: Failed to change the default quality profile: %+v", err)
 		}
 	}
 
-	return fmt.Errorf("resourceSonarqubeQualityProfileRead: Failed to find project: %+v", d.Id())
+	return resourceSonarqubeQualityProfileRead(d, m)
 }
 
 func resourceSonarqubeQualityProfileDelete(d *schema.ResourceData, m interface{}) error {
@@ -194,10 +214,12 @@ func resourceSonarqubeQualityProfileDelete(d *schema.ResourceData, m interface{}
 		"language":       []string{d.Get("language").(string)},
 	}.Encode()
 
-	err := setDefaultQualityProfile(d, m, false)
-
-	if err != nil {
-		return err
+	// If this is the default quality profile then we need to default it back to the built-in profile so there is still a default
+	if d.Get("is_default").(bool) {
+		err := setDefaultQualityProfile(d, m, false)
+		if err != nil {
+			return err
+		}
 	}
 
 	resp, err := httpRequestHelper(
@@ -224,20 +246,27 @@ func resourceSonarqubeQualityProfileImport(d *schema.ResourceData, m interface{}
 }
 
 func setDefaultQualityProfile(d *schema.ResourceData, m interface{}, setDefault bool) error {
+	profileName := d.Get("name").(string)
+	if !setDefault {
+		// Fall back to the built-in profile of the language so there is still a default
+		builtInProfile, err := getBuiltInQualityProfileName(d.Get("language").(string), m)
+		if err != nil {
+			return err
+		}
+		profileName = builtInProfile
+	}
+
+	return setDefaultQualityProfileByName(profileName, d.Get("language").(string), m)
+}
+
+// setDefaultQualityProfileByName makes the named quality profile the default profile of its language
+func setDefaultQualityProfileByName(profileName string, language string, m interface{}) error {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/set_default"
-
-	if setDefault {
-		sonarQubeURL.RawQuery = url.Values{
-			"qualityProfile": []string{d.Get("name").(string)},
-			"language":       []string{d.Get("language").(string)},
-		}.Encode()
-	} else {
-		sonarQubeURL.RawQuery = url.Values{
-			"qualityProfile": []string{"Sonar way"},
-			"language":       []string{d.Get("language").(string)},
-		}.Encode()
-	}
+	sonarQubeURL.RawQuery = url.Values{
+		"qualityProfile": []string{profileName},
+		"language":       []string{language},
+	}.Encode()
 
 	resp, err := httpRequestHelper(
 		m.(*ProviderConfiguration).httpClient,
@@ -275,3 +304,114 @@ func setParentQualityProfile(d *schema.ResourceData, m interface{}) error {
 	defer resp.Body.Close()
 	return nil
 }
+
+func renameQualityProfile(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/rename"
+
+	sonarQubeURL.RawQuery = url.Values{
+		"key":  []string{d.Id()},
+		"name": []string{d.Get("name").(string)},
+	}.Encode()
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"renameQualityProfile",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
+// getBuiltInQualityProfileName returns the name of the built-in profile of a language, preferring "Sonar way"
+func getBuiltInQualityProfileName(language string, m interface{}) (string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"language": []string{language},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"getBuiltInQualityProfileName",
+	)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	getQualityProfileResponse := GetQualityProfileList{}
+	err = json.NewDecoder(resp.Body).Decode(&getQualityProfileResponse)
+	if err != nil {
+		return "", fmt.Errorf("getBuiltInQualityProfileName: Failed to decode json into struct: %+v", err)
+	}
+
+	builtInProfile := ""
+	for _, value := range getQualityProfileResponse.Profiles {
+		if value.IsBuiltIn {
+			if value.Name == "Sonar way" {
+				return value.Name, nil
+			}
+			if builtInProfile == "" {
+				builtInProfile = value.Name
+			}
+		}
+	}
+	if builtInProfile == "" {
+		return "", fmt.Errorf("getBuiltInQualityProfileName: Failed to find a built-in quality profile for language '%s'", language)
+	}
+	return builtInProfile, nil
+}
+
+// getQualityProfileByKey returns the quality profile with the given key, or nil when it does not exist
+func getQualityProfileByKey(profileKey string, m interface{}) (*GetQualityProfile, error) {
+	return findQualityProfile(profileKey, "", "", m)
+}
+
+// findQualityProfile returns the quality profile with the given key, or with the given name and language when no key is set.
+// It returns nil when no such profile exists.
+func findQualityProfile(profileKey string, name string, language string, m interface{}) (*GetQualityProfile, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/search"
+	if profileKey == "" {
+		sonarQubeURL.RawQuery = url.Values{
+			"language":       []string{language},
+			"qualityProfile": []string{name},
+		}.Encode()
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"findQualityProfile",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	getQualityProfileResponse := GetQualityProfileList{}
+	err = json.NewDecoder(resp.Body).Decode(&getQualityProfileResponse)
+	if err != nil {
+		return nil, fmt.Errorf("findQualityProfile: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, value := range getQualityProfileResponse.Profiles {
+		if (profileKey != "" && value.Key == profileKey) || (profileKey == "" && value.Name == name && value.Language == language) {
+			return &value, nil
+		}
+	}
+
+	return nil, nil
+}
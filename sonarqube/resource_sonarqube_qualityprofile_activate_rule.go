@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,10 +13,16 @@ import (
 )
 
 type Actives struct {
-	QProfile string   `json:"qProfile"`
-	Inherit  string   `json:"inherit"`
-	Severity string   `json:"severity"`
-	Params   []Params `json:"params"`
+	QProfile string         `json:"qProfile"`
+	Inherit  string         `json:"inherit"`
+	Severity string         `json:"severity"`
+	Params   []ActiveParams `json:"params"`
+}
+
+// ActiveParams used in Actives
+type ActiveParams struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
 }
 
 type GetActiveRules struct {
@@ -28,6 +35,7 @@ func resourceSonarqubeQualityProfileRule() *schema.Resource {
 		Create: resourceSonarqubeQualityProfileRuleCreate,
 		Delete: resourceSonarqubeQualityProfileRuleDelete,
 		Read:   resourceSonarqubeQualityProfileRuleRead,
+		Update: resourceSonarqubeQualityProfileRuleUpdate,
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeQualityProfileRuleImporter,
 		},
@@ -40,10 +48,10 @@ func resourceSonarqubeQualityProfileRule() *schema.Resource {
 				Description: "Quality Profile key. Can be obtained through api/qualityprofiles/search",
 			},
 			"params": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "Parameters as semi-colon list of =, for example 'params=key1=v1;key2=v2' (Only for custom rule)",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Parameters as semi-colon list of =, for example 'params=key1=v1;key2=v2' (Only for custom rule)",
+				DiffSuppressFunc: suppressEquivalentQualityProfileRuleParams,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -51,7 +59,6 @@ func resourceSonarqubeQualityProfileRule() *schema.Resource {
 			"reset": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 				Description: "Reset severity and parameters of activated rule. Set the values defined on parent profile or from rule default values.",
 				Default:     "false",
 				ValidateDiagFunc: validation.ToDiagFunc(
@@ -70,8 +77,8 @@ func resourceSonarqubeQualityProfileRule() *schema.Resource {
 			"severity": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				Description: "Rule severity",
-				ForceNew:    true,
 				ValidateDiagFunc: validation.ToDiagFunc(
 					validation.StringInSlice(
 						[]string{"INFO", "MINOR", "MAJOR", "CRITICAL", "BLOCKER"},
@@ -84,30 +91,20 @@ func resourceSonarqubeQualityProfileRule() *schema.Resource {
 }
 
 func resourceSonarqubeQualityProfileRuleCreate(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/activate_rule"
+	if err := activateQualityProfileRuleFromResourceData(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRuleCreate: Failed to activate rule: %+v", err)
+	}
 
-	sonarQubeURL.RawQuery = url.Values{
-		"key":      []string{d.Get("key").(string)},
-		"params":   []string{d.Get("params").(string)},
-		"reset":    []string{d.Get("reset").(string)},
-		"rule":     []string{d.Get("rule").(string)},
-		"severity": []string{d.Get("severity").(string)},
-	}.Encode()
+	d.SetId(createQualityProfileRuleId(d.Get("key").(string), d.Get("rule").(string)))
+	return resourceSonarqubeQualityProfileRuleRead(d, m)
+}
 
-	resp, err := httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"POST",
-		sonarQubeURL.String(),
-		http.StatusNoContent,
-		"resourceSonarqubeQualityProfileRuleCreate",
-	)
-	if err != nil {
-		return fmt.Errorf("resourceSonarqubeQualityProfileRuleCreate: Failed to delete quality profile: %+v", err)
+func resourceSonarqubeQualityProfileRuleUpdate(d *schema.ResourceData, m interface{}) error {
+	// Activating an already active rule updates its severity and parameters
+	if err := activateQualityProfileRuleFromResourceData(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRuleUpdate: Failed to update activated rule: %+v", err)
 	}
-	defer resp.Body.Close()
 
-	d.SetId(d.Get("rule").(string))
 	return resourceSonarqubeQualityProfileRuleRead(d, m)
 }
 
@@ -135,10 +132,18 @@ func resourceSonarqubeQualityProfileRuleDelete(d *schema.ResourceData, m interfa
 }
 
 func resourceSonarqubeQualityProfileRuleRead(d *schema.ResourceData, m interface{}) error {
+	// Resources created by older versions of the provider only have the rule key as ID
+	profileKey := d.Get("key").(string)
+	ruleKey := d.Id()
+	if idSlice := strings.SplitN(d.Id(), "/", 2); len(idSlice) == 2 {
+		profileKey = idSlice[0]
+		ruleKey = idSlice[1]
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/rules/show"
 	sonarQubeURL.RawQuery = url.Values{
-		"key":     []string{d.Id()},
+		"key":     []string{ruleKey},
 		"actives": []string{"true"},
 	}.Encode()
 	resp, err := httpRequestHelper(
@@ -148,8 +153,12 @@ func resourceSonarqubeQualityProfileRuleRead(d *schema.ResourceData, m interface
 		http.StatusOK,
 		"resourceSonarqubeQualityProfileRuleRead",
 	)
-
 	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			// Rule no longer exists
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 	defer resp.Body.Close()
@@ -160,17 +169,84 @@ func resourceSonarqubeQualityProfileRuleRead(d *schema.ResourceData, m interface
 		return fmt.Errorf("resourceSonarqubeQualityProfileRuleRead: Failed to decode json into struct: %+v", err)
 	}
 
-	if d.Id() == activeRuleReadResponse.Rule.RuleKey {
-		d.SetId(activeRuleReadResponse.Rule.RuleKey)
+	// Look for the activation of the rule in this quality profile
+	for _, active := range activeRuleReadResponse.Actives {
+		if active.QProfile != profileKey {
+			continue
+		}
+
+		d.SetId(createQualityProfileRuleId(profileKey, activeRuleReadResponse.Rule.RuleKey))
+		d.Set("key", profileKey)
+		d.Set("rule", activeRuleReadResponse.Rule.RuleKey)
+		d.Set("severity", active.Severity)
+
+		// Only the configured params are compared, the others keep their default value
+		configuredParams := decodeQualityProfileRuleParams(d.Get("params").(string))
+		if len(configuredParams) > 0 {
+			params := make(map[string]string)
+			for _, param := range active.Params {
+				if _, ok := configuredParams[param.Key]; ok {
+					params[param.Key] = param.Value
+				}
+			}
+			d.Set("params", encodeQualityProfileRuleParams(params))
+		}
 		return nil
 	}
 
-	return fmt.Errorf("resourceSonarqubeQualityProfileRuleRead: Failed to find project: %+v", d.Id())
+	// Rule is not active in the quality profile
+	d.SetId("")
+	return nil
 }
 
 func resourceSonarqubeQualityProfileRuleImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	idSlice := strings.SplitN(d.Id(), "/", 2)
+	if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileRuleImporter: Import ID must be in the format 'profileKey/ruleKey', got: %s", d.Id())
+	}
+
 	if err := resourceSonarqubeQualityProfileRuleRead(d, m); err != nil {
 		return nil, err
 	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileRuleImporter: Rule '%s' is not active in quality profile '%s'", idSlice[1], idSlice[0])
+	}
+	d.Set("reset", "false")
 	return []*schema.ResourceData{d}, nil
 }
+
+func activateQualityProfileRuleFromResourceData(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/activate_rule"
+
+	sonarQubeURL.RawQuery = url.Values{
+		"key":      []string{d.Get("key").(string)},
+		"params":   []string{d.Get("params").(string)},
+		"reset":    []string{d.Get("reset").(string)},
+		"rule":     []string{d.Get("rule").(string)},
+		"severity": []string{d.Get("severity").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"activateQualityProfileRuleFromResourceData",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// Params are equivalent when they contain the same keys and values, in any order
+func suppressEquivalentQualityProfileRuleParams(k, old, new string, d *schema.ResourceData) bool {
+	return reflect.DeepEqual(decodeQualityProfileRuleParams(old), decodeQualityProfileRuleParams(new))
+}
+
+func createQualityProfileRuleId(profileKey string, ruleKey string) string {
+	return profileKey + "/" + ruleKey
+}
@@ -57,15 +57,23 @@ func TestAccSonarqubeQualityprofileActivateRuleBasic(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "severity", "BLOCKER"),
 				),
 			},
+			{
+				Config: testAccSonarqubeQualityprofileActivateRuleBasicConfig(rnd, "testProfile", "activateRule", "MINOR"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "key"),
+					resource.TestCheckResourceAttrSet(name, "rule"),
+					resource.TestCheckResourceAttr(name, "severity", "MINOR"),
+				),
+			},
 			{
 				ResourceName:            name,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"key", "reset", "rule", "severity"},
+				ImportStateVerifyIgnore: []string{"reset"},
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(name, "key"),
 					resource.TestCheckResourceAttrSet(name, "rule"),
-					resource.TestCheckResourceAttr(name, "severity", "BLOCKER"),
+					resource.TestCheckResourceAttr(name, "severity", "MINOR"),
 				),
 			},
 		},
@@ -0,0 +1,399 @@
+package sonarqube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// QualityProfileBackup for unmarshalling the XML returned by api/qualityprofiles/backup
+type QualityProfileBackup struct {
+	XMLName  xml.Name                   `xml:"profile"`
+	Name     string                     `xml:"name"`
+	Language string                     `xml:"language"`
+	Rules    []QualityProfileBackupRule `xml:"rules>rule"`
+}
+
+// QualityProfileBackupRule used in QualityProfileBackup
+type QualityProfileBackupRule struct {
+	RepositoryKey string                          `xml:"repositoryKey"`
+	Key           string                          `xml:"key"`
+	Priority      string                          `xml:"priority"`
+	Parameters    []QualityProfileBackupParameter `xml:"parameters>parameter"`
+}
+
+// QualityProfileBackupParameter used in QualityProfileBackupRule
+type QualityProfileBackupParameter struct {
+	Key   string `xml:"key"`
+	Value string `xml:"value"`
+}
+
+// RestoreQualityProfileResponse for unmarshalling response body of api/qualityprofiles/restore
+type RestoreQualityProfileResponse struct {
+	Profile       QualityProfile `json:"profile"`
+	RuleSuccesses int            `json:"ruleSuccesses"`
+	RuleFailures  int            `json:"ruleFailures"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeQualityProfileBackup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSonarqubeQualityProfileBackupCreate,
+		Read:   resourceSonarqubeQualityProfileBackupRead,
+		Update: resourceSonarqubeQualityProfileBackupUpdate,
+		Delete: resourceSonarqubeQualityProfileBackupDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeQualityProfileBackupImport,
+		},
+		// Expose the rules of the backup so the plan shows drift per rule instead of one XML blob
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return customizeQualityProfileBackupDiff(d)
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"backup": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The quality profile backup in the XML format of api/qualityprofiles/backup",
+				DiffSuppressFunc: suppressEquivalentQualityProfileBackup,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Quality profile name, taken from the backup",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Quality profile language, taken from the backup",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Quality profile key",
+			},
+			"rule": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The rules that are active in the quality profile",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"severity": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"params": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSonarqubeQualityProfileBackupCreate(d *schema.ResourceData, m interface{}) error {
+	restoreResponse, err := restoreQualityProfileBackup(d.Get("backup").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBackupCreate: %+v", err)
+	}
+
+	d.SetId(restoreResponse.Profile.Key)
+	d.Set("name", restoreResponse.Profile.Name)
+	d.Set("language", restoreResponse.Profile.Language)
+	// The profile exists even when some rules failed, the missing rules show up as a diff in the next plan
+	warnQualityProfileBackupRuleFailures(restoreResponse)
+	return resourceSonarqubeQualityProfileBackupRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileBackupRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"language": []string{d.Get("language").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeQualityProfileBackupRead",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBackupRead: Failed to search quality profiles: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	getQualityProfileResponse := GetQualityProfileList{}
+	err = json.NewDecoder(resp.Body).Decode(&getQualityProfileResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBackupRead: Failed to decode json into struct: %+v", err)
+	}
+
+	var profile *GetQualityProfile
+	for i, value := range getQualityProfileResponse.Profiles {
+		if d.Id() == value.Key {
+			profile = &getQualityProfileResponse.Profiles[i]
+			break
+		}
+	}
+	if profile == nil {
+		// Quality profile not found
+		d.SetId("")
+		return nil
+	}
+
+	backup, err := downloadQualityProfileBackup(profile.Name, profile.Language, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBackupRead: %+v", err)
+	}
+
+	d.Set("name", profile.Name)
+	d.Set("language", profile.Language)
+	d.Set("key", profile.Key)
+	d.Set("rule", flattenQualityProfileBackupRules(backup.Rules))
+	return nil
+}
+
+func resourceSonarqubeQualityProfileBackupUpdate(d *schema.ResourceData, m interface{}) error {
+	restoreResponse, err := restoreQualityProfileBackup(d.Get("backup").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBackupUpdate: %+v", err)
+	}
+
+	warnQualityProfileBackupRuleFailures(restoreResponse)
+	return resourceSonarqubeQualityProfileBackupRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileBackupDelete(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/delete"
+	sonarQubeURL.RawQuery = url.Values{
+		"qualityProfile": []string{d.Get("name").(string)},
+		"language":       []string{d.Get("language").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeQualityProfileBackupDelete",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBackupDelete: Failed to delete quality profile: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func resourceSonarqubeQualityProfileBackupImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	profile, err := getQualityProfileByKey(d.Id(), m)
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileBackupImport: Failed to find quality profile '%s': %+v", d.Id(), err)
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileBackupImport: Quality profile '%s' not found", d.Id())
+	}
+
+	// Use the current backup so that an equivalent backup in the configuration does not cause a diff
+	backup, err := downloadQualityProfileBackupXML(profile.Name, profile.Language, m)
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileBackupImport: %+v", err)
+	}
+	d.Set("backup", backup)
+	d.Set("language", profile.Language)
+
+	if err := resourceSonarqubeQualityProfileBackupRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func warnQualityProfileBackupRuleFailures(restoreResponse *RestoreQualityProfileResponse) {
+	if restoreResponse.RuleFailures > 0 {
+		log.Printf("[WARN] %d rules of quality profile '%s' could not be restored", restoreResponse.RuleFailures, restoreResponse.Profile.Name)
+	}
+}
+
+func customizeQualityProfileBackupDiff(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("backup") {
+		return d.SetNewComputed("rule")
+	}
+
+	backup, err := parseQualityProfileBackup(d.Get("backup").(string))
+	if err != nil {
+		return fmt.Errorf("customizeQualityProfileBackupDiff: %+v", err)
+	}
+
+	// The backup decides which profile is restored, so a different profile means a new resource
+	for attribute, value := range map[string]string{"name": backup.Name, "language": backup.Language} {
+		if d.Get(attribute).(string) != value {
+			if err := d.SetNew(attribute, value); err != nil {
+				return err
+			}
+			if d.Id() != "" {
+				if err := d.ForceNew(attribute); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return d.SetNew("rule", flattenQualityProfileBackupRules(backup.Rules))
+}
+
+// Backups are equivalent when they restore the same profile with the same rules, severities and params
+func suppressEquivalentQualityProfileBackup(k, old, new string, d *schema.ResourceData) bool {
+	oldBackup, err := parseQualityProfileBackup(old)
+	if err != nil {
+		return false
+	}
+	newBackup, err := parseQualityProfileBackup(new)
+	if err != nil {
+		return false
+	}
+
+	oldRules, _ := json.Marshal(flattenQualityProfileBackupRules(oldBackup.Rules))
+	newRules, _ := json.Marshal(flattenQualityProfileBackupRules(newBackup.Rules))
+	return oldBackup.Name == newBackup.Name && oldBackup.Language == newBackup.Language && string(oldRules) == string(newRules)
+}
+
+func parseQualityProfileBackup(backup string) (*QualityProfileBackup, error) {
+	qualityProfileBackup := QualityProfileBackup{}
+	if err := xml.Unmarshal([]byte(backup), &qualityProfileBackup); err != nil {
+		return nil, fmt.Errorf("Failed to parse quality profile backup: %+v", err)
+	}
+	if qualityProfileBackup.Name == "" || qualityProfileBackup.Language == "" {
+		return nil, fmt.Errorf("Quality profile backup must contain a name and a language")
+	}
+	return &qualityProfileBackup, nil
+}
+
+func downloadQualityProfileBackup(name string, language string, m interface{}) (*QualityProfileBackup, error) {
+	backup, err := downloadQualityProfileBackupXML(name, language, m)
+	if err != nil {
+		return nil, err
+	}
+	return parseQualityProfileBackup(backup)
+}
+
+func downloadQualityProfileBackupXML(name string, language string, m interface{}) (string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/backup"
+	sonarQubeURL.RawQuery = url.Values{
+		"qualityProfile": []string{name},
+		"language":       []string{language},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"downloadQualityProfileBackupXML",
+	)
+	if err != nil {
+		return "", fmt.Errorf("Failed to download backup of quality profile '%s': %+v", name, err)
+	}
+	defer resp.Body.Close()
+
+	responseData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read backup of quality profile '%s': %+v", name, err)
+	}
+
+	return string(responseData), nil
+}
+
+func restoreQualityProfileBackup(backup string, m interface{}) (*RestoreQualityProfileResponse, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/restore"
+
+	// The backup has to be uploaded as a file
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("backup", "backup.xml")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to prepare quality profile backup upload: %+v", err)
+	}
+	if _, err = part.Write([]byte(backup)); err != nil {
+		return nil, fmt.Errorf("Failed to prepare quality profile backup upload: %+v", err)
+	}
+	if err = writer.Close(); err != nil {
+		return nil, fmt.Errorf("Failed to prepare quality profile backup upload: %+v", err)
+	}
+
+	resp, err := httpRequestBodyHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		body.Bytes(),
+		writer.FormDataContentType(),
+		http.StatusOK,
+		"restoreQualityProfileBackup",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to restore quality profile backup: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	restoreResponse := RestoreQualityProfileResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&restoreResponse)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode json into struct: %+v", err)
+	}
+
+	return &restoreResponse, nil
+}
+
+func flattenQualityProfileBackupRules(input []QualityProfileBackupRule) []interface{} {
+	flatRules := make([]interface{}, 0, len(input))
+
+	for _, rule := range input {
+		params := make(map[string]interface{})
+		for _, param := range rule.Parameters {
+			params[param.Key] = param.Value
+		}
+
+		flatRules = append(flatRules, map[string]interface{}{
+			"key":      rule.RepositoryKey + ":" + rule.Key,
+			"severity": rule.Priority,
+			"params":   params,
+		})
+	}
+
+	// Make sure the order is always the same for when we are comparing lists of rules
+	sort.Slice(flatRules, func(i, j int) bool {
+		return flatRules[i].(map[string]interface{})["key"].(string) < flatRules[j].(map[string]interface{})["key"].(string)
+	})
+
+	return flatRules
+}
@@ -0,0 +1,152 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_qualityprofile_backup", &resource.Sweeper{
+		Name: "sonarqube_qualityprofile_backup",
+		F:    testSweepSonarqubeQualityProfileBackupSweeper,
+	})
+}
+
+func testSweepSonarqubeQualityProfileBackupSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeQualityProfileBackupConfig(rnd string, name string, severity string, max string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile_backup" "%[1]s" {
+			backup = <<-EOT
+				<?xml version='1.0' encoding='UTF-8'?>
+				<profile>
+					<name>%[2]s</name>
+					<language>java</language>
+					<rules>
+						<rule>
+							<repositoryKey>java</repositoryKey>
+							<key>S1135</key>
+							<priority>%[3]s</priority>
+							<parameters/>
+						</rule>
+						<rule>
+							<repositoryKey>java</repositoryKey>
+							<key>S107</key>
+							<priority>MAJOR</priority>
+							<parameters>
+								<parameter>
+									<key>max</key>
+									<value>%[4]s</value>
+								</parameter>
+							</parameters>
+						</rule>
+					</rules>
+				</profile>
+			EOT
+		}`, rnd, name, severity, max)
+}
+
+func TestAccSonarqubeQualityProfileBackup(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_backup." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileBackupConfig(rnd, "testAccSonarqubeQualityProfileBackup", "INFO", "7"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeQualityProfileBackup"),
+					resource.TestCheckResourceAttr(name, "language", "java"),
+					resource.TestCheckResourceAttrSet(name, "key"),
+					resource.TestCheckResourceAttr(name, "rule.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":      "java:S1135",
+						"severity": "INFO",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":        "java:S107",
+						"params.max": "7",
+					}),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityProfileBackupConfig(rnd, "testAccSonarqubeQualityProfileBackup", "MINOR", "9"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":      "java:S1135",
+						"severity": "MINOR",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":        "java:S107",
+						"params.max": "9",
+					}),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The imported backup is the XML downloaded from SonarQube, which is formatted differently
+				ImportStateVerifyIgnore: []string{"backup"},
+			},
+		},
+	})
+}
+
+func testAccSonarqubeQualityProfileBackupUnknownRuleConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile_backup" "%[1]s" {
+			backup = <<-EOT
+				<?xml version='1.0' encoding='UTF-8'?>
+				<profile>
+					<name>%[2]s</name>
+					<language>java</language>
+					<rules>
+						<rule>
+							<repositoryKey>java</repositoryKey>
+							<key>S1135</key>
+							<priority>INFO</priority>
+							<parameters/>
+						</rule>
+						<rule>
+							<repositoryKey>java</repositoryKey>
+							<key>testAccUnknownRule</key>
+							<priority>MAJOR</priority>
+							<parameters/>
+						</rule>
+					</rules>
+				</profile>
+			EOT
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeQualityProfileBackupRuleFailures(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_backup." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// The profile is kept in state and the rule that could not be restored shows up as a diff
+				Config:             testAccSonarqubeQualityProfileBackupUnknownRuleConfig(rnd, "testAccSonarqubeQualityProfileBackupRuleFailures"),
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "key"),
+					resource.TestCheckResourceAttr(name, "rule.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":      "java:S1135",
+						"severity": "INFO",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,137 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeQualityProfileDefault() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSonarqubeQualityProfileDefaultCreate,
+		Read:   resourceSonarqubeQualityProfileDefaultRead,
+		Update: resourceSonarqubeQualityProfileDefaultUpdate,
+		Delete: resourceSonarqubeQualityProfileDefaultDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeQualityProfileDefaultImport,
+		},
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+				return validateLanguage(d, m)
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"language": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Quality profile language",
+			},
+			"quality_profile": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the quality profile that is the default of the language",
+			},
+			"fallback_quality_profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the quality profile that becomes the default again on destroy. Defaults to the built-in profile of the language",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeQualityProfileDefaultCreate(d *schema.ResourceData, m interface{}) error {
+	language := d.Get("language").(string)
+
+	err := setDefaultQualityProfileByName(d.Get("quality_profile").(string), language, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileDefaultCreate: Failed to set the default quality profile of language '%s': %+v", language, err)
+	}
+
+	d.SetId(language)
+	return resourceSonarqubeQualityProfileDefaultRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileDefaultRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"defaults": []string{"true"},
+		"language": []string{d.Id()},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeQualityProfileDefaultRead",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	getQualityProfileResponse := GetQualityProfileList{}
+	err = json.NewDecoder(resp.Body).Decode(&getQualityProfileResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileDefaultRead: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, value := range getQualityProfileResponse.Profiles {
+		if value.IsDefault && value.Language == d.Id() {
+			d.Set("language", value.Language)
+			d.Set("quality_profile", value.Name)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("resourceSonarqubeQualityProfileDefaultRead: Failed to find the default quality profile of language '%s'", d.Id())
+}
+
+func resourceSonarqubeQualityProfileDefaultUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("quality_profile") {
+		err := setDefaultQualityProfileByName(d.Get("quality_profile").(string), d.Id(), m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileDefaultUpdate: Failed to set the default quality profile of language '%s': %+v", d.Id(), err)
+		}
+	}
+
+	return resourceSonarqubeQualityProfileDefaultRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileDefaultDelete(d *schema.ResourceData, m interface{}) error {
+	fallbackProfile := d.Get("fallback_quality_profile").(string)
+	if fallbackProfile == "" {
+		builtInProfile, err := getBuiltInQualityProfileName(d.Id(), m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileDefaultDelete: %+v", err)
+		}
+		fallbackProfile = builtInProfile
+	}
+
+	err := setDefaultQualityProfileByName(fallbackProfile, d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileDefaultDelete: Failed to restore '%s' as default quality profile of language '%s': %+v", fallbackProfile, d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceSonarqubeQualityProfileDefaultImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSonarqubeQualityProfileDefaultRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,68 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_qualityprofile_default", &resource.Sweeper{
+		Name: "sonarqube_qualityprofile_default",
+		F:    testSweepSonarqubeQualityProfileDefaultSweeper,
+	})
+}
+
+func testSweepSonarqubeQualityProfileDefaultSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeQualityProfileDefaultConfig(rnd string, name string, defaultProfile string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s_a" {
+			name     = "%[2]sA"
+			language = "py"
+		}
+
+		resource "sonarqube_qualityprofile" "%[1]s_b" {
+			name     = "%[2]sB"
+			language = "py"
+		}
+
+		resource "sonarqube_qualityprofile_default" "%[1]s" {
+			language        = "py"
+			quality_profile = sonarqube_qualityprofile.%[1]s_%[3]s.name
+		}`, rnd, name, defaultProfile)
+}
+
+func TestAccSonarqubeQualityProfileDefault(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_default." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileDefaultConfig(rnd, "testAccSonarqubeQualityProfileDefault", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "language", "py"),
+					resource.TestCheckResourceAttr(name, "quality_profile", "testAccSonarqubeQualityProfileDefaultA"),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityProfileDefaultConfig(rnd, "testAccSonarqubeQualityProfileDefault", "b"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "quality_profile", "testAccSonarqubeQualityProfileDefaultB"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "py",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
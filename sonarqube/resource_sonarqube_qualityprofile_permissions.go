@@ -0,0 +1,162 @@
+package sonarqube
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeQualityProfilePermissions() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSonarqubeQualityProfilePermissionsCreate,
+		Read:   resourceSonarqubeQualityProfilePermissionsRead,
+		Update: resourceSonarqubeQualityProfilePermissionsUpdate,
+		Delete: resourceSonarqubeQualityProfilePermissionsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeQualityProfilePermissionsImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"quality_profile": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Quality profile name",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringLenBetween(0, 100),
+				),
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Quality profile language",
+			},
+			"users": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The logins of the users allowed to edit the Quality Profile",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"groups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The names of the groups allowed to edit the Quality Profile",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceSonarqubeQualityProfilePermissionsCreate(d *schema.ResourceData, m interface{}) error {
+	profileName := d.Get("quality_profile").(string)
+	language := d.Get("language").(string)
+
+	// Start from the permissions that already exist on the profile so the configured sets become authoritative
+	target := qualityProfileEditPermissionsTarget(profileName, language)
+	currentUsers, currentGroups, err := readEditPermissionsFromApi(target, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfilePermissionsCreate: Failed to read permissions of quality profile '%s': %+v", profileName, err)
+	}
+	if currentUsers == nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfilePermissionsCreate: Failed to find quality profile '%s' for language '%s'", profileName, language)
+	}
+
+	err = synchronizeEditPermissions(target, "user", currentUsers, expandStringSet(d.Get("users").(*schema.Set)), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfilePermissionsCreate: %+v", err)
+	}
+	err = synchronizeEditPermissions(target, "group", currentGroups, expandStringSet(d.Get("groups").(*schema.Set)), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfilePermissionsCreate: %+v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%v/%v", profileName, language))
+	return resourceSonarqubeQualityProfilePermissionsRead(d, m)
+}
+
+func resourceSonarqubeQualityProfilePermissionsRead(d *schema.ResourceData, m interface{}) error {
+	profileName, language, err := parseQualityProfilePermissionsId(d.Id())
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfilePermissionsRead: %+v", err)
+	}
+
+	users, groups, err := readEditPermissionsFromApi(qualityProfileEditPermissionsTarget(profileName, language), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfilePermissionsRead: Failed to read permissions of quality profile '%s': %+v", profileName, err)
+	}
+	if users == nil {
+		log.Printf("[WARN] Quality profile '%s' for language '%s' not found, removing permissions from state", profileName, language)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("quality_profile", profileName)
+	d.Set("language", language)
+	d.Set("users", users)
+	d.Set("groups", groups)
+	return nil
+}
+
+func resourceSonarqubeQualityProfilePermissionsUpdate(d *schema.ResourceData, m interface{}) error {
+	target := qualityProfileEditPermissionsTarget(d.Get("quality_profile").(string), d.Get("language").(string))
+
+	if d.HasChange("users") {
+		oldUsers, newUsers := d.GetChange("users")
+		err := synchronizeEditPermissions(target, "user", expandStringSet(oldUsers.(*schema.Set)), expandStringSet(newUsers.(*schema.Set)), m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfilePermissionsUpdate: %+v", err)
+		}
+	}
+
+	if d.HasChange("groups") {
+		oldGroups, newGroups := d.GetChange("groups")
+		err := synchronizeEditPermissions(target, "group", expandStringSet(oldGroups.(*schema.Set)), expandStringSet(newGroups.(*schema.Set)), m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfilePermissionsUpdate: %+v", err)
+		}
+	}
+
+	return resourceSonarqubeQualityProfilePermissionsRead(d, m)
+}
+
+func resourceSonarqubeQualityProfilePermissionsDelete(d *schema.ResourceData, m interface{}) error {
+	target := qualityProfileEditPermissionsTarget(d.Get("quality_profile").(string), d.Get("language").(string))
+
+	err := synchronizeEditPermissions(target, "user", expandStringSet(d.Get("users").(*schema.Set)), []string{}, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfilePermissionsDelete: %+v", err)
+	}
+	err = synchronizeEditPermissions(target, "group", expandStringSet(d.Get("groups").(*schema.Set)), []string{}, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfilePermissionsDelete: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceSonarqubeQualityProfilePermissionsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSonarqubeQualityProfilePermissionsRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseQualityProfilePermissionsId splits an id of the form "profileName/language".
+// Profile names may contain slashes, language keys never do.
+func parseQualityProfilePermissionsId(id string) (string, string, error) {
+	index := strings.LastIndex(id, "/")
+	if index <= 0 || index == len(id)-1 {
+		return "", "", fmt.Errorf("invalid id '%s', expected format 'profileName/language'", id)
+	}
+	return id[:index], id[index+1:], nil
+}
@@ -0,0 +1,98 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_qualityprofile_permissions", &resource.Sweeper{
+		Name: "sonarqube_qualityprofile_permissions",
+		F:    testSweepSonarqubeQualityprofilePermissionsSweeper,
+	})
+}
+
+// TODO: implement sweeper to clean up projects: https://www.terraform.io/docs/extend/testing/acceptance-tests/sweepers.html
+func testSweepSonarqubeQualityprofilePermissionsSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeQualityprofilePermissionsConfig(rnd string, name string, users string, groups string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name = "%[2]s"
+			name       = "%[2]s"
+			password   = "secret-sauce37!"
+		}
+
+		resource "sonarqube_group" "%[1]s" {
+			name        = "%[2]s"
+			description = "foo"
+		}
+
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "js"
+		}
+
+		resource "sonarqube_qualityprofile_permissions" "%[1]s" {
+			quality_profile = sonarqube_qualityprofile.%[1]s.name
+			language        = sonarqube_qualityprofile.%[1]s.language
+			users           = %[3]s
+			groups          = %[4]s
+		}`, rnd, name, users, groups)
+}
+
+func TestAccSonarqubeQualityprofilePermissions(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_permissions." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityprofilePermissionsConfig(rnd, "testAccQualityprofilePermissions", "[sonarqube_user."+rnd+".login_name]", "[sonarqube_group."+rnd+".name]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "quality_profile", "testAccQualityprofilePermissions"),
+					resource.TestCheckResourceAttr(name, "language", "js"),
+					resource.TestCheckResourceAttr(name, "users.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "users.*", "testAccQualityprofilePermissions"),
+					resource.TestCheckResourceAttr(name, "groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "groups.*", "testAccQualityprofilePermissions"),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityprofilePermissionsConfig(rnd, "testAccQualityprofilePermissions", "[]", "[sonarqube_group."+rnd+".name]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "users.#", "0"),
+					resource.TestCheckResourceAttr(name, "groups.#", "1"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "testAccQualityprofilePermissions/js",
+				ImportStateVerify: true,
+			},
+			{
+				// Permissions of a profile deleted outside of terraform are removed from the state
+				PreConfig: func() {
+					d := schema.TestResourceDataRaw(t, resourceSonarqubeQualityProfile().Schema, map[string]interface{}{
+						"name":     "testAccQualityprofilePermissions",
+						"language": "js",
+					})
+					if err := resourceSonarqubeQualityProfileDelete(d, testAccProvider.Meta()); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccSonarqubeQualityprofilePermissionsConfig(rnd, "testAccQualityprofilePermissions", "[]", "[sonarqube_group."+rnd+".name]"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
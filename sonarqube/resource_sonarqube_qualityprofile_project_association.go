@@ -1,12 +1,14 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -34,6 +36,11 @@ func resourceSonarqubeQualityProfileProjectAssociation() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeQualityProfileProjectAssociationImport,
 		},
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+				return validateLanguage(d, m)
+			},
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
@@ -0,0 +1,419 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/satori/uuid"
+)
+
+// BulkRuleChangeResponse for unmarshalling response body of the bulk activate_rules and deactivate_rules endpoints
+type BulkRuleChangeResponse struct {
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+// qualityProfileRuleQueryFilters maps the filter attributes of the resource to the parameters of the rules search
+var qualityProfileRuleQueryFilters = map[string]string{
+	"languages":    "languages",
+	"tags":         "tags",
+	"types":        "types",
+	"repositories": "repositories",
+	"severities":   "severities",
+	"cwe":          "cwe",
+	"owasp_top10":  "owaspTop10",
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeQualityProfileRuleQuery() *schema.Resource {
+	filterKeys := []string{"languages", "tags", "types", "repositories", "severities", "cwe", "owasp_top10"}
+
+	return &schema.Resource{
+		Create:        resourceSonarqubeQualityProfileRuleQueryCreate,
+		Read:          resourceSonarqubeQualityProfileRuleQueryRead,
+		Update:        resourceSonarqubeQualityProfileRuleQueryUpdate,
+		Delete:        resourceSonarqubeQualityProfileRuleQueryDelete,
+		CustomizeDiff: resourceSonarqubeQualityProfileRuleQueryCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeQualityProfileRuleQueryImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Quality Profile key. Can be obtained through api/qualityprofiles/search",
+			},
+			"languages": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: filterKeys,
+				Description:  "Languages of the rules to activate",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tags": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: filterKeys,
+				Description:  "Tags of the rules to activate",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"types": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: filterKeys,
+				Description:  "Types of the rules to activate",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(
+						validation.StringInSlice(
+							[]string{"CODE_SMELL", "BUG", "VULNERABILITY", "SECURITY_HOTSPOT"},
+							false,
+						),
+					),
+				},
+			},
+			"repositories": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: filterKeys,
+				Description:  "Repositories of the rules to activate",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"severities": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: filterKeys,
+				Description:  "Default severities of the rules to activate",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(
+						validation.StringInSlice(
+							[]string{"INFO", "MINOR", "MAJOR", "CRITICAL", "BLOCKER"},
+							false,
+						),
+					),
+				},
+			},
+			"cwe": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: filterKeys,
+				Description:  "CWE identifiers of the rules to activate",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"owasp_top10": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: filterKeys,
+				Description:  "OWASP Top 10 categories of the rules to activate",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(
+						validation.StringInSlice(
+							[]string{"a1", "a2", "a3", "a4", "a5", "a6", "a7", "a8", "a9", "a10"},
+							false,
+						),
+					),
+				},
+			},
+			"target_severity": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Severity of the activated rules. When unset the default severity of each rule is used",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice(
+						[]string{"INFO", "MINOR", "MAJOR", "CRITICAL", "BLOCKER"},
+						false,
+					),
+				),
+			},
+			"activated_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of rules matching the query that are active in the quality profile",
+			},
+			"inactive_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of rules matching the query that are not active in the quality profile",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeQualityProfileRuleQueryCreate(d *schema.ResourceData, m interface{}) error {
+	if err := activateQualityProfileRuleQuery(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRuleQueryCreate: %+v", err)
+	}
+
+	d.SetId(uuid.NewV4().String())
+	return resourceSonarqubeQualityProfileRuleQueryRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileRuleQueryRead(d *schema.ResourceData, m interface{}) error {
+	profileKey := d.Get("key").(string)
+
+	qualityProfile, err := getQualityProfileByKey(profileKey, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRuleQueryRead: %+v", err)
+	}
+	if qualityProfile == nil {
+		log.Printf("[WARN] Quality profile '%s' not found, removing rule query from state", profileKey)
+		d.SetId("")
+		return nil
+	}
+
+	query, ok := qualityProfileRuleQuery(d, qualityProfile.Language)
+	if !ok {
+		// The languages filter excludes the language of the profile, so no rule can match
+		d.Set("activated_count", 0)
+		d.Set("inactive_count", 0)
+		return nil
+	}
+
+	activated, err := countQualityProfileRuleQueryMatches(profileKey, query, true, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRuleQueryRead: %+v", err)
+	}
+	inactive, err := countQualityProfileRuleQueryMatches(profileKey, query, false, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRuleQueryRead: %+v", err)
+	}
+
+	d.Set("activated_count", activated)
+	d.Set("inactive_count", inactive)
+	return nil
+}
+
+func resourceSonarqubeQualityProfileRuleQueryUpdate(d *schema.ResourceData, m interface{}) error {
+	// Running the bulk activation again activates newly matching rules and applies the target severity to all of them
+	if err := activateQualityProfileRuleQuery(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRuleQueryUpdate: %+v", err)
+	}
+
+	return resourceSonarqubeQualityProfileRuleQueryRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileRuleQueryDelete(d *schema.ResourceData, m interface{}) error {
+	profileKey := d.Get("key").(string)
+
+	qualityProfile, err := getQualityProfileByKey(profileKey, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRuleQueryDelete: %+v", err)
+	}
+	if qualityProfile == nil {
+		return nil
+	}
+
+	query, ok := qualityProfileRuleQuery(d, qualityProfile.Language)
+	if !ok {
+		return nil
+	}
+	query.Set("targetKey", profileKey)
+	// Inherited rules cannot be deactivated and are skipped by SonarQube
+	query.Set("qprofile", profileKey)
+	query.Set("activation", "true")
+
+	result, err := bulkChangeQualityProfileRules("deactivate_rules", query, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRuleQueryDelete: Failed to deactivate rules of quality profile '%s': %+v", profileKey, err)
+	}
+	if result.Failed > 0 {
+		log.Printf("[WARN] %d rules of quality profile '%s' could not be deactivated", result.Failed, profileKey)
+	}
+
+	return nil
+}
+
+// resourceSonarqubeQualityProfileRuleQueryCustomizeDiff plans an update when rules matching the query are not active,
+// e.g. after a plugin installed new rules.
+func resourceSonarqubeQualityProfileRuleQueryCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || d.Get("inactive_count").(int) == 0 {
+		return nil
+	}
+
+	if err := d.SetNewComputed("activated_count"); err != nil {
+		return err
+	}
+	return d.SetNewComputed("inactive_count")
+}
+
+// activateQualityProfileRuleQuery activates all rules matching the query in the quality profile
+func activateQualityProfileRuleQuery(d *schema.ResourceData, m interface{}) error {
+	profileKey := d.Get("key").(string)
+
+	qualityProfile, err := getQualityProfileByKey(profileKey, m)
+	if err != nil {
+		return err
+	}
+	if qualityProfile == nil {
+		return fmt.Errorf("Failed to find quality profile '%s'", profileKey)
+	}
+
+	query, ok := qualityProfileRuleQuery(d, qualityProfile.Language)
+	if !ok {
+		return fmt.Errorf("The languages filter does not include '%s', the language of quality profile '%s'", qualityProfile.Language, profileKey)
+	}
+	query.Set("targetKey", profileKey)
+	if targetSeverity, ok := d.GetOk("target_severity"); ok {
+		query.Set("targetSeverity", targetSeverity.(string))
+	}
+
+	result, err := bulkChangeQualityProfileRules("activate_rules", query, m)
+	if err != nil {
+		return fmt.Errorf("Failed to activate rules in quality profile '%s': %+v", profileKey, err)
+	}
+	// Rules that cannot be activated would show up as drift on every plan, so the query has to exclude them
+	if result.Failed > 0 {
+		return fmt.Errorf("%d rules matching the query could not be activated in quality profile '%s', narrow the query to exclude them", result.Failed, profileKey)
+	}
+
+	return nil
+}
+
+// qualityProfileRuleQuery builds the rules search query from the configured filters.
+// The query is restricted to the language of the profile, as rules of other languages cannot be activated in it,
+// and to rules that are not deprecated or removed, as SonarQube refuses to activate those.
+// It returns false when the configured languages exclude the language of the profile.
+func qualityProfileRuleQuery(d *schema.ResourceData, language string) (url.Values, bool) {
+	query := url.Values{
+		"is_template": []string{"false"},
+		"statuses":    []string{"READY,BETA"},
+	}
+
+	for attribute, parameter := range qualityProfileRuleQueryFilters {
+		values := expandStringSet(d.Get(attribute).(*schema.Set))
+		if len(values) == 0 {
+			continue
+		}
+		if attribute == "languages" {
+			if !containsStringFold(values, language) {
+				return nil, false
+			}
+			continue
+		}
+		query.Set(parameter, strings.Join(values, ","))
+	}
+	query.Set("languages", language)
+
+	return query, true
+}
+
+// countQualityProfileRuleQueryMatches returns the number of rules matching the query that are (in)active in the profile
+func countQualityProfileRuleQueryMatches(profileKey string, query url.Values, active bool, m interface{}) (int, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/rules/search"
+
+	rawQuery := url.Values{}
+	for key, values := range query {
+		rawQuery[key] = values
+	}
+	rawQuery.Set("qprofile", profileKey)
+	rawQuery.Set("activation", fmt.Sprintf("%t", active))
+	rawQuery.Set("f", "name")
+	rawQuery.Set("ps", "1")
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"countQualityProfileRuleQueryMatches",
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	searchResponse := SearchActiveRules{}
+	err = json.NewDecoder(resp.Body).Decode(&searchResponse)
+	if err != nil {
+		return 0, fmt.Errorf("countQualityProfileRuleQueryMatches: Failed to decode json into struct: %+v", err)
+	}
+
+	return searchResponse.Total, nil
+}
+
+// bulkChangeQualityProfileRules calls the bulk activate_rules or deactivate_rules endpoint with the given query
+func bulkChangeQualityProfileRules(action string, query url.Values, m interface{}) (*BulkRuleChangeResponse, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/" + action
+	sonarQubeURL.RawQuery = query.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"bulkChangeQualityProfileRules",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	result := BulkRuleChangeResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("bulkChangeQualityProfileRules: Failed to decode json into struct: %+v", err)
+	}
+
+	return &result, nil
+}
+
+// resourceSonarqubeQualityProfileRuleQueryImport imports a rule query by "<profile key>/<filter>=<value>,<value>[/<filter>=...]",
+// e.g. "AU-Tpxb--iU5OvuD2FLy/types=VULNERABILITY/tags=cwe,owasp"
+func resourceSonarqubeQualityProfileRuleQueryImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	idSlice := strings.Split(d.Id(), "/")
+	if len(idSlice) < 2 || idSlice[0] == "" {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileRuleQueryImport: invalid id '%s', expected format '<profile key>/<filter>=<value>,<value>'", d.Id())
+	}
+
+	d.Set("key", idSlice[0])
+	for _, filter := range idSlice[1:] {
+		filterSlice := strings.SplitN(filter, "=", 2)
+		if _, ok := qualityProfileRuleQueryFilters[filterSlice[0]]; !ok || len(filterSlice) != 2 || filterSlice[1] == "" {
+			return nil, fmt.Errorf("resourceSonarqubeQualityProfileRuleQueryImport: invalid filter '%s' in id '%s'", filter, d.Id())
+		}
+		if err := d.Set(filterSlice[0], strings.Split(filterSlice[1], ",")); err != nil {
+			return nil, err
+		}
+	}
+
+	// The import id is kept as the id of the resource, as the generated id of the original resource cannot be recovered
+	if err := resourceSonarqubeQualityProfileRuleQueryRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileRuleQueryImport: Failed to find quality profile '%s'", idSlice[0])
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,79 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_qualityprofile_rule_query", &resource.Sweeper{
+		Name: "sonarqube_qualityprofile_rule_query",
+		F:    testSweepSonarqubeQualityProfileRuleQuerySweeper,
+	})
+}
+
+func testSweepSonarqubeQualityProfileRuleQuerySweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeQualityProfileRuleQueryConfig(rnd string, name string, severity string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "java"
+		}
+
+		resource "sonarqube_qualityprofile_rule_query" "%[1]s" {
+			key             = sonarqube_qualityprofile.%[1]s.key
+			types           = ["VULNERABILITY"]
+			tags            = ["cwe"]
+			target_severity = "%[3]s"
+		}`, rnd, name, severity)
+}
+
+func TestAccSonarqubeQualityProfileRuleQuery(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_rule_query." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileRuleQueryConfig(rnd, "testAccSonarqubeQualityProfileRuleQuery", "CRITICAL"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "key"),
+					resource.TestCheckResourceAttr(name, "target_severity", "CRITICAL"),
+					resource.TestCheckResourceAttrSet(name, "activated_count"),
+					resource.TestCheckResourceAttr(name, "inactive_count", "0"),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityProfileRuleQueryConfig(rnd, "testAccSonarqubeQualityProfileRuleQuery", "BLOCKER"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "target_severity", "BLOCKER"),
+					resource.TestCheckResourceAttr(name, "inactive_count", "0"),
+				),
+			},
+			{
+				ResourceName: name,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources[name].Primary.Attributes["key"] + "/types=VULNERABILITY/tags=cwe", nil
+				},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["types.#"] != "1" || states[0].Attributes["tags.#"] != "1" {
+						return fmt.Errorf("unexpected imported state: %+v", states)
+					}
+					if states[0].Attributes["inactive_count"] != "0" {
+						return fmt.Errorf("expected no inactive rules after import, got %s", states[0].Attributes["inactive_count"])
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
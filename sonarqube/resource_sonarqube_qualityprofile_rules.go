@@ -0,0 +1,722 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// SearchActiveRules for unmarshalling response body of api/rules/search with active rules
+type SearchActiveRules struct {
+	Total   int                  `json:"total"`
+	P       int                  `json:"p"`
+	PS      int                  `json:"ps"`
+	Rules   []Rule               `json:"rules"`
+	Actives map[string][]Actives `json:"actives"`
+}
+
+// qualityProfileRulesBulkMinimum is the number of rules of a repository from which a bulk change is attempted.
+// Smaller sets are cheaper to change one by one than to compare with the rules of the repository.
+const qualityProfileRulesBulkMinimum = 10
+
+// QualityProfileActiveRule is an active rule of a quality profile
+type QualityProfileActiveRule struct {
+	Key      string
+	Severity string
+	Inherit  string
+	Params   map[string]string
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeQualityProfileRules() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSonarqubeQualityProfileRulesCreate,
+		Read:   resourceSonarqubeQualityProfileRulesRead,
+		Update: resourceSonarqubeQualityProfileRulesUpdate,
+		Delete: resourceSonarqubeQualityProfileRulesDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeQualityProfileRulesImport,
+		},
+		CustomizeDiff: customdiff.All(
+			resourceSonarqubeQualityProfileRulesCustomizeDiff,
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Quality Profile key. Can be obtained through api/qualityprofiles/search",
+			},
+			"include_inherited": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether rules inherited from the parent profile are managed by this resource",
+			},
+			"rule": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The complete set of rules that are active in the quality profile",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Rule key",
+						},
+						"severity": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Rule severity",
+							ValidateDiagFunc: validation.ToDiagFunc(
+								validation.StringInSlice(
+									[]string{"INFO", "MINOR", "MAJOR", "CRITICAL", "BLOCKER"},
+									false,
+								),
+							),
+						},
+						"params": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Rule parameters",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSonarqubeQualityProfileRulesCreate(d *schema.ResourceData, m interface{}) error {
+	profileKey := d.Get("key").(string)
+
+	// Start from the rules that are already active so the configured set becomes authoritative
+	activeRules, err := readQualityProfileActiveRulesFromApi(profileKey, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRulesCreate: Failed to read active rules of quality profile '%s': %+v", profileKey, err)
+	}
+	current := filterQualityProfileActiveRules(activeRules, d.Get("include_inherited").(bool), nil)
+
+	err = synchronizeQualityProfileRules(profileKey, current, expandQualityProfileRules(d.Get("rule").(*schema.Set)), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRulesCreate: %+v", err)
+	}
+
+	d.SetId(profileKey)
+	return resourceSonarqubeQualityProfileRulesRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileRulesRead(d *schema.ResourceData, m interface{}) error {
+	qualityProfile, err := getQualityProfileByKey(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRulesRead: %+v", err)
+	}
+	if qualityProfile == nil {
+		log.Printf("[WARN] Quality profile '%s' not found, removing rules from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	activeRules, err := readQualityProfileActiveRulesFromApi(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRulesRead: Failed to read active rules of quality profile '%s': %+v", d.Id(), err)
+	}
+
+	configured := expandQualityProfileRules(d.Get("rule").(*schema.Set))
+	rules := filterQualityProfileActiveRules(activeRules, d.Get("include_inherited").(bool), configured)
+
+	d.Set("key", d.Id())
+	d.Set("rule", flattenQualityProfileActiveRules(rules, configured))
+	return nil
+}
+
+func resourceSonarqubeQualityProfileRulesUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChanges("rule", "include_inherited") {
+		activeRules, err := readQualityProfileActiveRulesFromApi(d.Id(), m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileRulesUpdate: Failed to read active rules of quality profile '%s': %+v", d.Id(), err)
+		}
+
+		oldRules, newRules := d.GetChange("rule")
+		desired := expandQualityProfileRules(newRules.(*schema.Set))
+		current := managedQualityProfileRules(expandQualityProfileRules(oldRules.(*schema.Set)), activeRules, d.Get("include_inherited").(bool), desired)
+		if err := synchronizeQualityProfileRules(d.Id(), current, desired, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileRulesUpdate: %+v", err)
+		}
+	}
+
+	return resourceSonarqubeQualityProfileRulesRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileRulesDelete(d *schema.ResourceData, m interface{}) error {
+	rules := expandQualityProfileRules(d.Get("rule").(*schema.Set))
+	if len(rules) == 0 {
+		return nil
+	}
+
+	qualityProfile, err := getQualityProfileByKey(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRulesDelete: %+v", err)
+	}
+	if qualityProfile == nil {
+		return nil
+	}
+	activeRules, err := readQualityProfileActiveRulesFromApi(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRulesDelete: Failed to read active rules of quality profile '%s': %+v", d.Id(), err)
+	}
+
+	// Only the rules of the state are deactivated. Rules inherited from the parent profile cannot be deactivated and are skipped.
+	deactivate := make([]QualityProfileActiveRule, 0)
+	for _, rule := range rules {
+		active := findQualityProfileActiveRule(activeRules, rule.Key)
+		if active == nil {
+			continue
+		}
+		if active.Inherit == "INHERITED" || active.Inherit == "OVERRIDES" {
+			log.Printf("[WARN] Rule '%s' is inherited from the parent of quality profile '%s' and is not deactivated", rule.Key, d.Id())
+			continue
+		}
+		deactivate = append(deactivate, *active)
+	}
+
+	remaining, err := bulkDeactivateQualityProfileRules(qualityProfile, deactivate, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRulesDelete: %+v", err)
+	}
+	for _, rule := range remaining {
+		if err := deactivateQualityProfileRule(d.Id(), rule.Key, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileRulesDelete: Failed to deactivate rule '%s' in quality profile '%s': %+v", rule.Key, d.Id(), err)
+		}
+	}
+
+	return nil
+}
+
+// resourceSonarqubeQualityProfileRulesCustomizeDiff fails the plan when a managed rule inherited from the parent profile is removed,
+// as SonarQube cannot deactivate inherited rules
+func resourceSonarqubeQualityProfileRulesCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChanges("rule", "include_inherited") {
+		return nil
+	}
+
+	oldRules, newRules := d.GetChange("rule")
+	desired := expandQualityProfileRules(newRules.(*schema.Set))
+	removed := make([]string, 0)
+	for _, rule := range expandQualityProfileRules(oldRules.(*schema.Set)) {
+		if findQualityProfileActiveRule(desired, rule.Key) == nil {
+			removed = append(removed, rule.Key)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	activeRules, err := readQualityProfileActiveRulesFromApi(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRulesCustomizeDiff: Failed to read active rules of quality profile '%s': %+v", d.Id(), err)
+	}
+	// Inherited rules that are no longer managed because of include_inherited are not removed from the profile
+	current := managedQualityProfileRules(expandQualityProfileRules(oldRules.(*schema.Set)), activeRules, d.Get("include_inherited").(bool), desired)
+	for _, ruleKey := range removed {
+		if rule := findQualityProfileActiveRule(current, ruleKey); rule != nil && rule.Inherit == "INHERITED" {
+			return fmt.Errorf("rule '%s' is inherited from the parent of quality profile '%s' and cannot be removed, deactivate it in the parent profile instead", ruleKey, d.Id())
+		}
+	}
+	return nil
+}
+
+func resourceSonarqubeQualityProfileRulesImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("include_inherited", false)
+	if err := resourceSonarqubeQualityProfileRulesRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// readQualityProfileActiveRulesFromApi pages through api/rules/search to return all active rules of a quality profile
+func readQualityProfileActiveRulesFromApi(profileKey string, m interface{}) ([]QualityProfileActiveRule, error) {
+	activeRules := make([]QualityProfileActiveRule, 0)
+
+	page := 1
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/rules/search"
+		sonarQubeURL.RawQuery = url.Values{
+			"qprofile":   []string{profileKey},
+			"activation": []string{"true"},
+			"f":          []string{"actives"},
+			"p":          []string{strconv.Itoa(page)},
+			"ps":         []string{"500"},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"readQualityProfileActiveRulesFromApi",
+		)
+		if err != nil {
+			return nil, err
+		}
+		// Decode response into struct
+		searchResponse := SearchActiveRules{}
+		err = json.NewDecoder(resp.Body).Decode(&searchResponse)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("readQualityProfileActiveRulesFromApi: Failed to decode json into struct: %+v", err)
+		}
+
+		for _, rule := range searchResponse.Rules {
+			for _, active := range searchResponse.Actives[rule.RuleKey] {
+				if active.QProfile != profileKey {
+					continue
+				}
+				params := make(map[string]string)
+				for _, param := range active.Params {
+					params[param.Key] = param.Value
+				}
+				activeRules = append(activeRules, QualityProfileActiveRule{
+					Key:      rule.RuleKey,
+					Severity: active.Severity,
+					Inherit:  active.Inherit,
+					Params:   params,
+				})
+			}
+		}
+
+		// Stop once the last page has been read
+		if len(searchResponse.Rules) == 0 || searchResponse.P*searchResponse.PS >= searchResponse.Total {
+			break
+		}
+		page++
+	}
+
+	return activeRules, nil
+}
+
+// filterQualityProfileActiveRules drops inherited rules unless they are managed
+func filterQualityProfileActiveRules(activeRules []QualityProfileActiveRule, includeInherited bool, configured []QualityProfileActiveRule) []QualityProfileActiveRule {
+	filtered := make([]QualityProfileActiveRule, 0)
+	for _, rule := range activeRules {
+		if rule.Inherit == "INHERITED" && !includeInherited && findQualityProfileActiveRule(configured, rule.Key) == nil {
+			continue
+		}
+		filtered = append(filtered, rule)
+	}
+	return filtered
+}
+
+// managedQualityProfileRules returns the rules that are managed with the given include_inherited value.
+// The inheritance of the rules is taken from the active rules of the quality profile.
+func managedQualityProfileRules(rules []QualityProfileActiveRule, activeRules []QualityProfileActiveRule, includeInherited bool, configured []QualityProfileActiveRule) []QualityProfileActiveRule {
+	annotated := make([]QualityProfileActiveRule, 0, len(rules))
+	for _, rule := range rules {
+		if active := findQualityProfileActiveRule(activeRules, rule.Key); active != nil {
+			rule.Inherit = active.Inherit
+		}
+		annotated = append(annotated, rule)
+	}
+	return filterQualityProfileActiveRules(annotated, includeInherited, configured)
+}
+
+// synchronizeQualityProfileRules activates and deactivates rules so that the quality profile matches the desired rules.
+// New rules without params are activated in bulk where possible, all other changes are made rule by rule.
+func synchronizeQualityProfileRules(profileKey string, current []QualityProfileActiveRule, desired []QualityProfileActiveRule, m interface{}) error {
+	changed := make([]QualityProfileActiveRule, 0)
+	pending := make([]QualityProfileActiveRule, 0)
+	for _, rule := range desired {
+		existing := findQualityProfileActiveRule(current, rule.Key)
+		if existing != nil && existing.Severity == rule.Severity && qualityProfileRuleParamsEqual(existing.Params, rule.Params) {
+			continue
+		}
+		if existing == nil && len(rule.Params) == 0 {
+			pending = append(pending, rule)
+		} else {
+			changed = append(changed, rule)
+		}
+	}
+
+	remaining, err := bulkActivateQualityProfileRules(profileKey, pending, m)
+	if err != nil {
+		return err
+	}
+	for _, rule := range append(changed, remaining...) {
+		if err := activateQualityProfileRule(profileKey, rule.Key, rule.Severity, rule.Params, m); err != nil {
+			return fmt.Errorf("Failed to activate rule '%s' in quality profile '%s': %+v", rule.Key, profileKey, err)
+		}
+	}
+
+	for _, rule := range current {
+		if findQualityProfileActiveRule(desired, rule.Key) != nil {
+			continue
+		}
+		if rule.Inherit == "INHERITED" {
+			return fmt.Errorf("Rule '%s' is inherited from the parent of quality profile '%s' and cannot be deactivated", rule.Key, profileKey)
+		}
+		if err := deactivateQualityProfileRule(profileKey, rule.Key, m); err != nil {
+			return fmt.Errorf("Failed to deactivate rule '%s' in quality profile '%s': %+v", rule.Key, profileKey, err)
+		}
+	}
+
+	return nil
+}
+
+// bulkActivateQualityProfileRules activates new rules without params with api/qualityprofiles/activate_rules.
+// The bulk endpoint activates every rule matching a rules search, and the search cannot select a list of rule keys
+// (rule_key only takes a single key and q is a free text search). A repository is therefore only activated in bulk when
+// the rules to activate are exactly its inactive rules. The rules that still have to be activated one by one are returned.
+func bulkActivateQualityProfileRules(profileKey string, rules []QualityProfileActiveRule, m interface{}) ([]QualityProfileActiveRule, error) {
+	if len(rules) < qualityProfileRulesBulkMinimum {
+		return rules, nil
+	}
+
+	qualityProfile, err := getQualityProfileByKey(profileKey, m)
+	if err != nil {
+		return nil, err
+	}
+	if qualityProfile == nil {
+		return nil, fmt.Errorf("Failed to find quality profile '%s'", profileKey)
+	}
+
+	remaining := make([]QualityProfileActiveRule, 0)
+	for repository, repositoryRules := range groupQualityProfileRulesByRepository(rules) {
+		if len(repositoryRules) < qualityProfileRulesBulkMinimum {
+			remaining = append(remaining, repositoryRules...)
+			continue
+		}
+
+		query := url.Values{
+			"repositories": []string{repository},
+			"languages":    []string{qualityProfile.Language},
+			"is_template":  []string{"false"},
+			"qprofile":     []string{profileKey},
+			"activation":   []string{"false"},
+		}
+		inactiveRuleKeys, err := searchQualityProfileRuleKeys(query, m)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to search inactive rules of repository '%s': %+v", repository, err)
+		}
+		if !qualityProfileRuleKeysEqual(inactiveRuleKeys, repositoryRules) {
+			remaining = append(remaining, repositoryRules...)
+			continue
+		}
+
+		// Activate with the most common severity, the other rules get their severity afterwards
+		severity := mostCommonQualityProfileRuleSeverity(repositoryRules)
+		query.Set("targetKey", profileKey)
+		query.Set("targetSeverity", severity)
+		result, err := bulkChangeQualityProfileRules("activate_rules", query, m)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to activate rules of repository '%s' in quality profile '%s': %+v", repository, profileKey, err)
+		}
+		if result.Failed > 0 {
+			return nil, fmt.Errorf("%d rules of repository '%s' could not be activated in quality profile '%s'", result.Failed, repository, profileKey)
+		}
+		for _, rule := range repositoryRules {
+			if rule.Severity != severity {
+				remaining = append(remaining, rule)
+			}
+		}
+	}
+
+	return remaining, nil
+}
+
+// bulkDeactivateQualityProfileRules deactivates rules with api/qualityprofiles/deactivate_rules. Like the bulk activation,
+// a repository is only deactivated in bulk when the rules to deactivate are exactly its active rules that are not inherited.
+// The rules that still have to be deactivated one by one are returned.
+func bulkDeactivateQualityProfileRules(qualityProfile *GetQualityProfile, rules []QualityProfileActiveRule, m interface{}) ([]QualityProfileActiveRule, error) {
+	if len(rules) < qualityProfileRulesBulkMinimum {
+		return rules, nil
+	}
+
+	remaining := make([]QualityProfileActiveRule, 0)
+	for repository, repositoryRules := range groupQualityProfileRulesByRepository(rules) {
+		if len(repositoryRules) < qualityProfileRulesBulkMinimum {
+			remaining = append(remaining, repositoryRules...)
+			continue
+		}
+
+		query := url.Values{
+			"repositories": []string{repository},
+			"languages":    []string{qualityProfile.Language},
+			"qprofile":     []string{qualityProfile.Key},
+			"activation":   []string{"true"},
+			"inheritance":  []string{"NONE"},
+		}
+		activeRuleKeys, err := searchQualityProfileRuleKeys(query, m)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to search active rules of repository '%s': %+v", repository, err)
+		}
+		if !qualityProfileRuleKeysEqual(activeRuleKeys, repositoryRules) {
+			remaining = append(remaining, repositoryRules...)
+			continue
+		}
+
+		query.Set("targetKey", qualityProfile.Key)
+		result, err := bulkChangeQualityProfileRules("deactivate_rules", query, m)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to deactivate rules of repository '%s' in quality profile '%s': %+v", repository, qualityProfile.Key, err)
+		}
+		if result.Failed > 0 {
+			return nil, fmt.Errorf("%d rules of repository '%s' could not be deactivated in quality profile '%s'", result.Failed, repository, qualityProfile.Key)
+		}
+	}
+
+	return remaining, nil
+}
+
+// groupQualityProfileRulesByRepository groups rules by the repository part of their key
+func groupQualityProfileRulesByRepository(rules []QualityProfileActiveRule) map[string][]QualityProfileActiveRule {
+	repositories := make(map[string][]QualityProfileActiveRule)
+	for _, rule := range rules {
+		repository := strings.SplitN(rule.Key, ":", 2)[0]
+		repositories[repository] = append(repositories[repository], rule)
+	}
+	return repositories
+}
+
+// searchQualityProfileRuleKeys returns the keys of all rules matching the rules search
+func searchQualityProfileRuleKeys(query url.Values, m interface{}) ([]string, error) {
+	ruleKeys := make([]string, 0)
+
+	page := 1
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/rules/search"
+		rawQuery := url.Values{}
+		for key, values := range query {
+			rawQuery[key] = values
+		}
+		rawQuery.Set("f", "repo")
+		rawQuery.Set("p", strconv.Itoa(page))
+		rawQuery.Set("ps", "500")
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"searchQualityProfileRuleKeys",
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// Decode response into struct
+		searchResponse := SearchActiveRules{}
+		err = json.NewDecoder(resp.Body).Decode(&searchResponse)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("searchQualityProfileRuleKeys: Failed to decode json into struct: %+v", err)
+		}
+
+		for _, rule := range searchResponse.Rules {
+			ruleKeys = append(ruleKeys, rule.RuleKey)
+		}
+
+		// Stop once the last page has been read
+		if len(searchResponse.Rules) == 0 || searchResponse.P*searchResponse.PS >= searchResponse.Total {
+			break
+		}
+		page++
+	}
+
+	return ruleKeys, nil
+}
+
+// qualityProfileRuleKeysEqual reports whether the rule keys are exactly the keys of the rules
+func qualityProfileRuleKeysEqual(ruleKeys []string, rules []QualityProfileActiveRule) bool {
+	if len(ruleKeys) != len(rules) {
+		return false
+	}
+	for _, ruleKey := range ruleKeys {
+		if findQualityProfileActiveRule(rules, ruleKey) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// mostCommonQualityProfileRuleSeverity returns the severity used by most of the rules
+func mostCommonQualityProfileRuleSeverity(rules []QualityProfileActiveRule) string {
+	counts := make(map[string]int)
+	for _, rule := range rules {
+		counts[rule.Severity]++
+	}
+
+	severity := ""
+	for _, candidate := range []string{"INFO", "MINOR", "MAJOR", "CRITICAL", "BLOCKER"} {
+		if counts[candidate] > counts[severity] {
+			severity = candidate
+		}
+	}
+	return severity
+}
+
+func activateQualityProfileRule(profileKey string, ruleKey string, severity string, params map[string]string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/activate_rule"
+
+	rawQuery := url.Values{
+		"key":  []string{profileKey},
+		"rule": []string{ruleKey},
+	}
+	if severity != "" {
+		rawQuery.Add("severity", severity)
+	}
+	if len(params) > 0 {
+		rawQuery.Add("params", encodeQualityProfileRuleParams(params))
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"activateQualityProfileRule",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func deactivateQualityProfileRule(profileKey string, ruleKey string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/deactivate_rule"
+	sonarQubeURL.RawQuery = url.Values{
+		"key":  []string{profileKey},
+		"rule": []string{ruleKey},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"deactivateQualityProfileRule",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func findQualityProfileActiveRule(rules []QualityProfileActiveRule, ruleKey string) *QualityProfileActiveRule {
+	for i := range rules {
+		if rules[i].Key == ruleKey {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// Only the params set in the configuration are compared, the others keep their default value
+func qualityProfileRuleParamsEqual(current map[string]string, desired map[string]string) bool {
+	for key, value := range desired {
+		if current[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Encodes params in the 'key1=v1;key2=v2' format of api/qualityprofiles/activate_rule
+func encodeQualityProfileRuleParams(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	encoded := make([]string, 0, len(keys))
+	for _, key := range keys {
+		encoded = append(encoded, key+"="+params[key])
+	}
+	return strings.Join(encoded, ";")
+}
+
+// Decodes params in the 'key1=v1;key2=v2' format of api/qualityprofiles/activate_rule
+func decodeQualityProfileRuleParams(params string) map[string]string {
+	decoded := make(map[string]string)
+	for _, param := range strings.Split(params, ";") {
+		if strings.TrimSpace(param) == "" {
+			continue
+		}
+		keyValue := strings.SplitN(param, "=", 2)
+		if len(keyValue) == 2 {
+			decoded[strings.TrimSpace(keyValue[0])] = keyValue[1]
+		} else {
+			decoded[strings.TrimSpace(keyValue[0])] = ""
+		}
+	}
+	return decoded
+}
+
+func expandQualityProfileRules(input *schema.Set) []QualityProfileActiveRule {
+	rules := make([]QualityProfileActiveRule, 0)
+	for _, value := range input.List() {
+		rule := value.(map[string]interface{})
+		params := make(map[string]string)
+		for key, param := range rule["params"].(map[string]interface{}) {
+			params[key] = param.(string)
+		}
+		rules = append(rules, QualityProfileActiveRule{
+			Key:      rule["key"].(string),
+			Severity: rule["severity"].(string),
+			Params:   params,
+		})
+	}
+	return rules
+}
+
+// flattenQualityProfileActiveRules only returns the params that are configured for a rule, so default values do not cause a diff.
+// When nothing is configured for a rule (e.g. on import) all of its params are returned.
+func flattenQualityProfileActiveRules(input []QualityProfileActiveRule, configured []QualityProfileActiveRule) []interface{} {
+	flatRules := make([]interface{}, 0, len(input))
+
+	for _, rule := range input {
+		params := make(map[string]interface{})
+		configuredRule := findQualityProfileActiveRule(configured, rule.Key)
+		for key, value := range rule.Params {
+			if configuredRule != nil {
+				if _, ok := configuredRule.Params[key]; !ok {
+					continue
+				}
+			}
+			params[key] = value
+		}
+
+		flatRules = append(flatRules, map[string]interface{}{
+			"key":      rule.Key,
+			"severity": rule.Severity,
+			"params":   params,
+		})
+	}
+
+	return flatRules
+}
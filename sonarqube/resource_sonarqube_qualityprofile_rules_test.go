@@ -0,0 +1,275 @@
+package sonarqube
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_qualityprofile_rules", &resource.Sweeper{
+		Name: "sonarqube_qualityprofile_rules",
+		F:    testSweepSonarqubeQualityProfileRulesSweeper,
+	})
+}
+
+func testSweepSonarqubeQualityProfileRulesSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeQualityProfileRulesConfig(rnd string, name string, severity string, max string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "java"
+		}
+
+		resource "sonarqube_qualityprofile_rules" "%[1]s" {
+			key = sonarqube_qualityprofile.%[1]s.key
+
+			rule {
+				key      = "java:S1135"
+				severity = "%[3]s"
+			}
+
+			rule {
+				key      = "java:S107"
+				severity = "MAJOR"
+				params = {
+					max = "%[4]s"
+				}
+			}
+		}`, rnd, name, severity, max)
+}
+
+func TestAccSonarqubeQualityProfileRules(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_rules." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileRulesConfig(rnd, "testAccSonarqubeQualityProfileRules", "INFO", "7"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "key"),
+					resource.TestCheckResourceAttr(name, "rule.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":      "java:S1135",
+						"severity": "INFO",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":        "java:S107",
+						"params.max": "7",
+					}),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityProfileRulesConfig(rnd, "testAccSonarqubeQualityProfileRules", "MINOR", "9"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "rule.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":      "java:S1135",
+						"severity": "MINOR",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":        "java:S107",
+						"params.max": "9",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccSonarqubeQualityProfileRulesRepositoryConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "xml"
+		}
+
+		data "sonarqube_rules" "%[1]s" {
+			languages    = ["xml"]
+			repositories = ["xml"]
+			is_template  = false
+		}
+
+		resource "sonarqube_qualityprofile_rules" "%[1]s" {
+			key = sonarqube_qualityprofile.%[1]s.key
+
+			dynamic "rule" {
+				for_each = data.sonarqube_rules.%[1]s.rules
+				content {
+					key      = rule.value.key
+					severity = rule.key == 0 ? "BLOCKER" : "MINOR"
+				}
+			}
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeQualityProfileRulesRepository(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_rules." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// Activating a complete repository uses the bulk endpoint
+				Config: testAccSonarqubeQualityProfileRulesRepositoryConfig(rnd, "testAccSonarqubeQualityProfileRulesRepository"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name, "rule.#", "data.sonarqube_rules."+rnd, "total"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"severity": "BLOCKER",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"severity": "MINOR",
+					}),
+				),
+			},
+			{
+				// Destroying the rules deactivates the complete repository with the bulk endpoint
+				Config: fmt.Sprintf(`
+					resource "sonarqube_qualityprofile" "%[1]s" {
+						name     = "%[2]s"
+						language = "xml"
+					}`, rnd, "testAccSonarqubeQualityProfileRulesRepository"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSonarqubeQualityProfileRuleCount("sonarqube_qualityprofile."+rnd, 0),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSonarqubeQualityProfileRuleCount checks the number of active rules in the quality profile of a resource
+func testAccCheckSonarqubeQualityProfileRuleCount(profileName string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[profileName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", profileName)
+		}
+
+		activeRules, err := readQualityProfileActiveRulesFromApi(rs.Primary.ID, testAccProvider.Meta())
+		if err != nil {
+			return err
+		}
+		if len(activeRules) != count {
+			return fmt.Errorf("expected %d active rules in quality profile '%s', got %d", count, rs.Primary.ID, len(activeRules))
+		}
+		return nil
+	}
+}
+
+func testAccSonarqubeQualityProfileRulesInheritedProfilesConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s_parent" {
+			name     = "%[2]s-parent"
+			language = "java"
+		}
+
+		resource "sonarqube_qualityprofile_rules" "%[1]s_parent" {
+			key = sonarqube_qualityprofile.%[1]s_parent.key
+
+			rule {
+				key      = "java:S1135"
+				severity = "INFO"
+			}
+		}
+
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "java"
+			parent   = sonarqube_qualityprofile.%[1]s_parent.name
+
+			depends_on = [sonarqube_qualityprofile_rules.%[1]s_parent]
+		}`, rnd, name)
+}
+
+func testAccSonarqubeQualityProfileRulesInheritedConfig(rnd string, name string, includeInherited bool, childRules string) string {
+	return testAccSonarqubeQualityProfileRulesInheritedProfilesConfig(rnd, name) + fmt.Sprintf(`
+
+		resource "sonarqube_qualityprofile_rules" "%[1]s" {
+			key               = sonarqube_qualityprofile.%[1]s.key
+			include_inherited = %[2]t
+
+			rule {
+				key      = "java:S107"
+				severity = "MAJOR"
+			}
+			%[3]s
+		}`, rnd, includeInherited, childRules)
+}
+
+func TestAccSonarqubeQualityProfileRulesInherited(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_rules." + rnd
+	profileName := "sonarqube_qualityprofile." + rnd
+	inheritedRule := `
+			rule {
+				key      = "java:S1135"
+				severity = "INFO"
+			}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileRulesInheritedConfig(rnd, "testAccSonarqubeQualityProfileRulesInherited", true, inheritedRule),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "rule.#", "2"),
+				),
+			},
+			{
+				// Inherited rules cannot be deactivated, which is reported at plan time
+				Config:      testAccSonarqubeQualityProfileRulesInheritedConfig(rnd, "testAccSonarqubeQualityProfileRulesInherited", true, ""),
+				ExpectError: regexp.MustCompile("is inherited from the parent"),
+			},
+			{
+				// Inherited rules that are no longer managed are left alone
+				Config: testAccSonarqubeQualityProfileRulesInheritedConfig(rnd, "testAccSonarqubeQualityProfileRulesInherited", false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "rule.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key": "java:S107",
+					}),
+					testAccCheckSonarqubeQualityProfileRuleActive(profileName, "java:S1135", true),
+				),
+			},
+			{
+				// Destroying the rules only deactivates the rules of the state
+				Config: testAccSonarqubeQualityProfileRulesInheritedProfilesConfig(rnd, "testAccSonarqubeQualityProfileRulesInherited"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSonarqubeQualityProfileRuleActive(profileName, "java:S107", false),
+					testAccCheckSonarqubeQualityProfileRuleActive(profileName, "java:S1135", true),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSonarqubeQualityProfileRuleActive checks whether a rule is active in the quality profile of a resource
+func testAccCheckSonarqubeQualityProfileRuleActive(profileName string, ruleKey string, active bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[profileName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", profileName)
+		}
+
+		activeRules, err := readQualityProfileActiveRulesFromApi(rs.Primary.ID, testAccProvider.Meta())
+		if err != nil {
+			return err
+		}
+		if (findQualityProfileActiveRule(activeRules, ruleKey) != nil) != active {
+			return fmt.Errorf("expected rule '%s' to be active in quality profile '%s': %t", ruleKey, rs.Primary.ID, active)
+		}
+		return nil
+	}
+}
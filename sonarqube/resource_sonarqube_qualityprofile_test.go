@@ -2,9 +2,12 @@ package sonarqube
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func init() {
@@ -26,6 +29,62 @@ func testAccSonarqubeQualityProfileBasicConfig(rnd string, name string, language
 		}`, rnd, name, language)
 }
 
+func testAccSonarqubeQualityProfileUpdateConfig(rnd string, name string, language string, isDefault bool, parent string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name       = "%[2]s"
+			language   = "%[3]s"
+			is_default = %[4]t
+			parent     = "%[5]s"
+		}`, rnd, name, language, isDefault, parent)
+}
+
+func TestAccSonarqubeQualityProfileUpdate(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile." + rnd
+	var key string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileUpdateConfig(rnd, "testAccSonarqubeQualityProfileUpdate", "js", false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeQualityProfileUpdate"),
+					resource.TestCheckResourceAttr(name, "is_default", "false"),
+					resource.TestCheckResourceAttr(name, "parent", ""),
+					func(s *terraform.State) error {
+						key = s.RootModule().Resources[name].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityProfileUpdateConfig(rnd, "testAccSonarqubeQualityProfileRenamed", "js", true, "Sonar way"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeQualityProfileRenamed"),
+					resource.TestCheckResourceAttr(name, "is_default", "true"),
+					resource.TestCheckResourceAttr(name, "parent", "Sonar way"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources[name].Primary.ID != key {
+							return fmt.Errorf("quality profile was recreated instead of updated in place")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityProfileUpdateConfig(rnd, "testAccSonarqubeQualityProfileRenamed", "js", false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "is_default", "false"),
+					resource.TestCheckResourceAttr(name, "parent", ""),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSonarqubeQualityProfileBasic(t *testing.T) {
 	rnd := generateRandomResourceName()
 	name := "sonarqube_qualityprofile." + rnd
@@ -51,6 +110,37 @@ func TestAccSonarqubeQualityProfileBasic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(name, "key"),
 				),
 			},
+			{
+				// A quality profile deleted outside of terraform is created again
+				PreConfig: func() {
+					d := schema.TestResourceDataRaw(t, resourceSonarqubeQualityProfile().Schema, map[string]interface{}{
+						"name":     "testAccSonarqubeQualityProfile",
+						"language": "js",
+					})
+					if err := resourceSonarqubeQualityProfileDelete(d, testAccProvider.Meta()); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccSonarqubeQualityProfileBasicConfig(rnd, "testAccSonarqubeQualityProfile", "js"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeQualityProfile"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSonarqubeQualityProfileUnsupportedLanguage(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeQualityProfileBasicConfig(rnd, "testAccSonarqubeQualityProfileUnsupportedLanguage", "cobolscript"),
+				ExpectError: regexp.MustCompile("Language 'cobolscript' is not supported by the server"),
+			},
 		},
 	})
 }
@@ -0,0 +1,154 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GetRuleShow for unmarshalling response body of api/rules/show
+type GetRuleShow struct {
+	Rule Rule `json:"rule"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeRuleMetadata() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSonarqubeRuleMetadataCreate,
+		Read:   resourceSonarqubeRuleMetadataRead,
+		Update: resourceSonarqubeRuleMetadataUpdate,
+		Delete: resourceSonarqubeRuleMetadataDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeRuleMetadataImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Key of the rule",
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Custom tags of the rule. System tags defined by the rule itself are not affected",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"markdown_note": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Note on the rule in markdown format",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeRuleMetadataCreate(d *schema.ResourceData, m interface{}) error {
+	ruleKey := d.Get("key").(string)
+
+	err := updateRuleMetadata(ruleKey, expandStringSet(d.Get("tags").(*schema.Set)), d.Get("markdown_note").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeRuleMetadataCreate: Failed to update metadata of rule '%s': %+v", ruleKey, err)
+	}
+
+	d.SetId(ruleKey)
+	return resourceSonarqubeRuleMetadataRead(d, m)
+}
+
+func resourceSonarqubeRuleMetadataRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/rules/show"
+	sonarQubeURL.RawQuery = url.Values{
+		"key": []string{d.Id()},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeRuleMetadataRead",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			// Rule no longer exists
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	ruleShowResponse := GetRuleShow{}
+	err = json.NewDecoder(resp.Body).Decode(&ruleShowResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeRuleMetadataRead: Failed to decode json into struct: %+v", err)
+	}
+
+	d.Set("key", ruleShowResponse.Rule.RuleKey)
+	d.Set("tags", ruleShowResponse.Rule.Tags)
+	d.Set("markdown_note", ruleShowResponse.Rule.MdNote)
+	return nil
+}
+
+func resourceSonarqubeRuleMetadataUpdate(d *schema.ResourceData, m interface{}) error {
+	err := updateRuleMetadata(d.Id(), expandStringSet(d.Get("tags").(*schema.Set)), d.Get("markdown_note").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeRuleMetadataUpdate: Failed to update metadata of rule '%s': %+v", d.Id(), err)
+	}
+
+	return resourceSonarqubeRuleMetadataRead(d, m)
+}
+
+func resourceSonarqubeRuleMetadataDelete(d *schema.ResourceData, m interface{}) error {
+	err := updateRuleMetadata(d.Id(), []string{}, "", m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeRuleMetadataDelete: Failed to reset metadata of rule '%s': %+v", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceSonarqubeRuleMetadataImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSonarqubeRuleMetadataRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeRuleMetadataImport: Failed to find rule")
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// updateRuleMetadata sets the custom tags and the note of a rule. Empty values remove the current tags or note.
+func updateRuleMetadata(ruleKey string, tags []string, markdownNote string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/rules/update"
+	sonarQubeURL.RawQuery = url.Values{
+		"key":           []string{ruleKey},
+		"tags":          []string{strings.Join(tags, ",")},
+		"markdown_note": []string{markdownNote},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"updateRuleMetadata",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_rule_metadata", &resource.Sweeper{
+		Name: "sonarqube_rule_metadata",
+		F:    testSweepSonarqubeRuleMetadataSweeper,
+	})
+}
+
+func testSweepSonarqubeRuleMetadataSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeRuleMetadataConfig(rnd string, tags string, note string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_rule_metadata" "%[1]s" {
+			key           = "java:S1135"
+			tags          = %[2]s
+			markdown_note = "%[3]s"
+		}`, rnd, tags, note)
+}
+
+func TestAccSonarqubeRuleMetadata(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_rule_metadata." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeRuleMetadataConfig(rnd, `["internal", "reviewed"]`, "See the internal guideline"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "key", "java:S1135"),
+					resource.TestCheckResourceAttr(name, "tags.#", "2"),
+					resource.TestCheckTypeSetElemAttr(name, "tags.*", "internal"),
+					resource.TestCheckResourceAttr(name, "markdown_note", "See the internal guideline"),
+				),
+			},
+			{
+				Config: testAccSonarqubeRuleMetadataConfig(rnd, `["internal"]`, "See the updated internal guideline"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "tags.#", "1"),
+					resource.TestCheckResourceAttr(name, "markdown_note", "See the updated internal guideline"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "java:S1135",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -1,14 +1,20 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/exp/slices"
 )
 
 type Rule struct {
@@ -19,6 +25,7 @@ type Rule struct {
 	UpdatedAt   string   `json:"updatedAt"`
 	HtmlDesc    string   `json:"htmlDesc,omitempty"`
 	MdDesc      string   `json:"mdDesc,omitempty"`
+	MdNote      string   `json:"mdNote,omitempty"`
 	Severity    string   `json:"severity"`
 	Status      string   `json:"status"`
 	InternalKey string   `json:"internalKey"`
@@ -61,6 +68,11 @@ func resourceSonarqubeRule() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeRuleImporter,
 		},
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+				return validateRuleParams(d, m)
+			},
+		),
 
 		Schema: map[string]*schema.Schema{
 			"custom_key": {
@@ -87,9 +99,20 @@ func resourceSonarqubeRule() *schema.Resource {
 				),
 			},
 			"params": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Parameters as semi-colon list of =, for example 'params=key1=v1;key2=v2' (Only for custom rule)",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Parameters as semi-colon list of =, for example 'params=key1=v1;key2=v2' (Only for custom rule)",
+				DiffSuppressFunc: suppressEquivalentQualityProfileRuleParams,
+				ConflictsWith:    []string{"parameters"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"parameters": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Description:   "Parameters of the custom rule, as defined by the template rule",
+				ConflictsWith: []string{"params"},
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -157,7 +180,7 @@ func resourceSonarqubeRuleCreate(d *schema.ResourceData, m interface{}) error {
 		"customKey":           []string{d.Get("custom_key").(string)},
 		"markdownDescription": []string{d.Get("markdown_description").(string)},
 		"name":                []string{d.Get("name").(string)},
-		"params":              []string{d.Get("params").(string)},
+		"params":              []string{ruleParamsFromResourceData(d)},
 		"preventReactivation": []string{d.Get("prevent_reactivation").(string)},
 		"severity":            []string{d.Get("severity").(string)},
 		"status":              []string{d.Get("status").(string)},
@@ -220,6 +243,11 @@ func resourceSonarqubeRuleRead(d *schema.ResourceData, m interface{}) error {
 			d.Set("template_key", value.TemplateKey)
 			d.Set("status", value.Status)
 			d.Set("type", value.Type)
+
+			// Only the configured parameters are compared, the others keep the value of the template
+			if configured := d.Get("parameters").(map[string]interface{}); len(configured) > 0 {
+				d.Set("parameters", flattenRuleParameters(value.Params, configured))
+			}
 			return nil
 		}
 	}
@@ -252,9 +280,32 @@ func resourceSonarqubeRuleImporter(d *schema.ResourceData, m interface{}) ([]*sc
 	if err := resourceSonarqubeRuleRead(d, m); err != nil {
 		return nil, err
 	}
+
+	// Nothing is configured during an import, so all parameters that have a value are imported
+	rule, err := getRuleFromApi(d.Id(), m)
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeRuleImporter: Failed to read rule '%s': %+v", d.Id(), err)
+	}
+	d.Set("parameters", flattenRuleParameters(rule.Params, nil))
 	return []*schema.ResourceData{d}, nil
 }
 
+// flattenRuleParameters returns the values of the configured parameters, or of all parameters that have a value when
+// nothing is configured
+func flattenRuleParameters(params []Params, configured map[string]interface{}) map[string]string {
+	parameters := make(map[string]string)
+	for _, param := range params {
+		if configured == nil {
+			if param.DefaultValue != "" {
+				parameters[param.ParmKey] = param.DefaultValue
+			}
+		} else if _, ok := configured[param.ParmKey]; ok {
+			parameters[param.ParmKey] = param.DefaultValue
+		}
+	}
+	return parameters
+}
+
 func resourceSonarqubeRuleUpdate(d *schema.ResourceData, m interface{}) error {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/rules/update"
@@ -262,7 +313,7 @@ func resourceSonarqubeRuleUpdate(d *schema.ResourceData, m interface{}) error {
 		"key":                  []string{d.Id()},
 		"markdown_description": []string{d.Get("markdown_description").(string)},
 		"name":                 []string{d.Get("name").(string)},
-		"params":               []string{d.Get("params").(string)},
+		"params":               []string{ruleParamsFromResourceData(d)},
 		"severity":             []string{d.Get("severity").(string)},
 		"status":               []string{d.Get("status").(string)},
 	}.Encode()
@@ -281,3 +332,200 @@ func resourceSonarqubeRuleUpdate(d *schema.ResourceData, m interface{}) error {
 
 	return resourceSonarqubeRuleRead(d, m)
 }
+
+// ruleParamsFromResourceData returns the parameters of the rule in the format of the api, from either params or parameters
+func ruleParamsFromResourceData(d *schema.ResourceData) string {
+	if parameters := d.Get("parameters").(map[string]interface{}); len(parameters) > 0 {
+		params := make(map[string]string)
+		for key, value := range parameters {
+			params[key] = value.(string)
+		}
+		return encodeQualityProfileRuleParams(params)
+	}
+	return d.Get("params").(string)
+}
+
+// getRuleFromApi returns the rule with the given key using api/rules/show
+func getRuleFromApi(ruleKey string, m interface{}) (*Rule, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/rules/show"
+	sonarQubeURL.RawQuery = url.Values{
+		"key": []string{ruleKey},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"getRuleFromApi",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	ruleShowResponse := GetRuleShow{}
+	err = json.NewDecoder(resp.Body).Decode(&ruleShowResponse)
+	if err != nil {
+		return nil, fmt.Errorf("getRuleFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &ruleShowResponse.Rule, nil
+}
+
+// validateRuleParams checks the parameters of a custom rule against the parameters defined by its template
+func validateRuleParams(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChange("params") && !d.HasChange("parameters") && !d.HasChange("template_key") {
+		return nil
+	}
+	// Values that are only known after apply cannot be validated
+	if !d.NewValueKnown("template_key") || !d.NewValueKnown("params") || !d.NewValueKnown("parameters") {
+		return nil
+	}
+
+	params := decodeQualityProfileRuleParams(d.Get("params").(string))
+	for key, value := range d.Get("parameters").(map[string]interface{}) {
+		params[key] = value.(string)
+	}
+
+	templateKey := d.Get("template_key").(string)
+	template, err := getRuleFromApi(templateKey, m)
+	if err != nil {
+		return fmt.Errorf("validateRuleParams: Failed to read template rule '%s': %+v", templateKey, err)
+	}
+	if !template.IsTemplate {
+		return fmt.Errorf("validateRuleParams: Rule '%s' is not a template rule", templateKey)
+	}
+
+	templateParams := make(map[string]Params)
+	templateParamKeys := make([]string, 0, len(template.Params))
+	for _, param := range template.Params {
+		templateParams[param.ParmKey] = param
+		templateParamKeys = append(templateParamKeys, param.ParmKey)
+	}
+	sort.Strings(templateParamKeys)
+
+	for key, value := range params {
+		param, ok := templateParams[key]
+		if !ok {
+			return fmt.Errorf("validateRuleParams: Template rule '%s' has no parameter '%s'. Valid parameters are: %s", templateKey, key, strings.Join(templateParamKeys, ", "))
+		}
+		if err := validateRuleParamValue(param.Type, value); err != nil {
+			return fmt.Errorf("validateRuleParams: Invalid value '%s' for parameter '%s' of template rule '%s': %+v", value, key, templateKey, err)
+		}
+	}
+
+	// Numeric and boolean parameters cannot be left empty when the template does not define a default value.
+	// Text parameters (STRING, TEXT and REGULAR_EXPRESSION) may be empty, so they are never required.
+	for _, key := range templateParamKeys {
+		param := templateParams[key]
+		if _, ok := params[key]; ok || param.DefaultValue != "" {
+			continue
+		}
+		switch ruleParamBaseType(param.Type) {
+		case "INTEGER", "FLOAT", "BOOLEAN":
+			return fmt.Errorf("validateRuleParams: Parameter '%s' of template rule '%s' is required", key, templateKey)
+		}
+	}
+
+	return nil
+}
+
+// ruleParamBaseType returns the type of a rule parameter without its options, e.g. SINGLE_SELECT_LIST for 'SINGLE_SELECT_LIST,values="a,b"'
+func ruleParamBaseType(paramType string) string {
+	return strings.SplitN(paramType, ",", 2)[0]
+}
+
+var ruleParamSelectValuesRegexp = regexp.MustCompile(`values="([^"]*)"`)
+
+// validateRuleParamValue checks a parameter value against the type of the template parameter
+func validateRuleParamValue(paramType string, value string) error {
+	switch ruleParamBaseType(paramType) {
+	case "INTEGER":
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("expected an integer")
+		}
+	case "FLOAT":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("expected a number")
+		}
+	case "BOOLEAN":
+		if value != "true" && value != "false" {
+			return fmt.Errorf("expected true or false")
+		}
+	case "REGULAR_EXPRESSION":
+		if err := validateRegularExpressionBalance(value); err != nil {
+			return fmt.Errorf("expected a regular expression: %+v", err)
+		}
+	case "SINGLE_SELECT_LIST":
+		match := ruleParamSelectValuesRegexp.FindStringSubmatch(paramType)
+		if match == nil {
+			return nil
+		}
+		allowed := strings.Split(match[1], ",")
+		selected := []string{value}
+		if strings.Contains(paramType, "multiple=true") {
+			selected = strings.Split(value, ",")
+		}
+		for _, item := range selected {
+			if item != "" && !slices.Contains(allowed, item) {
+				return fmt.Errorf("expected one of: %s", match[1])
+			}
+		}
+	}
+	return nil
+}
+
+// validateRegularExpressionBalance only reports unbalanced brackets and parens and a trailing backslash. The server uses
+// java regular expressions, which support constructs that Go does not, so everything else is left to the server.
+func validateRegularExpressionBalance(value string) error {
+	parens := 0
+	brackets := 0
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '\\':
+			if i+1 == len(value) {
+				return fmt.Errorf("trailing backslash")
+			}
+			// Everything between \Q and \E is quoted
+			if value[i+1] == 'Q' {
+				end := strings.Index(value[i+2:], `\E`)
+				if end < 0 {
+					return nil
+				}
+				i += end + 3
+				continue
+			}
+			i++
+		case c == '[':
+			brackets++
+			// A ] directly after [ or [^ is a literal
+			if i+1 < len(value) && value[i+1] == '^' {
+				i++
+			}
+			if i+1 < len(value) && value[i+1] == ']' {
+				i++
+			}
+		case c == ']' && brackets > 0:
+			brackets--
+		case brackets > 0:
+			// Parens inside a character class are literals
+		case c == '(':
+			parens++
+		case c == ')':
+			if parens == 0 {
+				return fmt.Errorf("unmatched closing ')' at position %d", i)
+			}
+			parens--
+		}
+	}
+	if brackets > 0 {
+		return fmt.Errorf("missing closing ]")
+	}
+	if parens > 0 {
+		return fmt.Errorf("missing closing )")
+	}
+	return nil
+}
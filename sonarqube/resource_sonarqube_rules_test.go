@@ -2,6 +2,7 @@ package sonarqube
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -69,3 +70,97 @@ func TestAccSonarqubeRuleBasic(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeRuleParametersConfig(rnd string, custom_key string, parameters string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_rule" "%[1]s" {
+			custom_key           = "%[2]s"
+			markdown_description = "markdown_description"
+			name                 = "name"
+			template_key         = "xml:XPathCheck"
+			severity             = "INFO"
+			type                 = "CODE_SMELL"
+			parameters           = %[3]s
+		}`, rnd, custom_key, parameters)
+}
+
+func TestAccSonarqubeRuleParameters(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_rule." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeRuleParametersConfig(rnd, "parametersRule", `{ unknown = "value" }`),
+				ExpectError: regexp.MustCompile("has no parameter 'unknown'"),
+			},
+			{
+				Config: testAccSonarqubeRuleParametersConfig(rnd, "parametersRule", `{ expression = "//dependencies", filePattern = "**/pom.xml" }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "parameters.%", "2"),
+					resource.TestCheckResourceAttr(name, "parameters.expression", "//dependencies"),
+					resource.TestCheckResourceAttr(name, "parameters.filePattern", "**/pom.xml"),
+				),
+			},
+			{
+				Config: testAccSonarqubeRuleParametersConfig(rnd, "parametersRule", `{ filePattern = "**/pom.xml", expression = "//dependency" }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "parameters.expression", "//dependency"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"custom_key", "prevent_reactivation"},
+			},
+		},
+	})
+}
+
+func testAccSonarqubeRuleRegularExpressionConfig(rnd string, regularExpression string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_rule" "%[1]s" {
+			custom_key           = "javaRegularExpressionRule"
+			markdown_description = "markdown_description"
+			name                 = "name"
+			template_key         = "java:S124"
+			severity             = "INFO"
+			type                 = "CODE_SMELL"
+			parameters           = {
+				regularExpression = %[2]q
+			}
+		}`, rnd, regularExpression)
+}
+
+func TestAccSonarqubeRuleJavaRegularExpression(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_rule." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// Unbalanced brackets and parens are rejected at plan time
+				Config:      testAccSonarqubeRuleRegularExpressionConfig(rnd, `(?<=TODO\s+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("missing closing \\)"),
+			},
+			{
+				Config:      testAccSonarqubeRuleRegularExpressionConfig(rnd, `TODO[a-z`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("missing closing ]"),
+			},
+			{
+				// Lookbehinds, possessive quantifiers and quoted parens are valid java regular expressions
+				Config: testAccSonarqubeRuleRegularExpressionConfig(rnd, `(?<=TODO)\s++[\w(]+\Q)\E`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "parameters.regularExpression", `(?<=TODO)\s++[\w(]+\Q)\E`),
+				),
+			},
+		},
+	})
+}
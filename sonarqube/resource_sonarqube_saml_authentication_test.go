@@ -0,0 +1,92 @@
+package sonarqube
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// A self-signed certificate that is only used to test the validation of the resource
+const testAccSonarqubeSamlAuthenticationCertificate = `-----BEGIN CERTIFICATE-----
+MIIDHTCCAgWgAwIBAgIUQUIiZWFCf05e2cDJ8/JXcpHfEvowDQYJKoZIhvcNAQEL
+BQAwHTEbMBkGA1UEAwwSdGVycmFmb3JtLXRlc3QtaWRwMCAXDTI2MTAxODIwMzM0
+NFoYDzIxMjYwOTI0MjAzMzQ0WjAdMRswGQYDVQQDDBJ0ZXJyYWZvcm0tdGVzdC1p
+ZHAwggEiMA0GCSqGSIb3DQEBAQUAA4IBDwAwggEKAoIBAQCtSAuBV6WJuuxFEGyr
+LJ2TJsgQx/zOaO+lKEVhX9GAfH73WEySK4OLTyJi7P3XRg2WZUZwT3vuUjHqhAXR
+Kk8rVuJTnQGwt940ZSlU+h8VCj+9iqY0SMiT0pXNepnST+dHGuhz8MVwUJCkz83b
+ImVrglmlB/N2Uu3GX3Qtj71PNMNfRmc6fKT8jO0XMEdtsauv1unDV6hDBw+iFDLR
+ZqaAYxkNMusg8dqmnhMD96HOq8N7c6jqyoJLMh4KTmSJ1b3Wqrf+nW4fsHA8RrAv
+Vo3jOixupkZJh1rFoglcw11G22P5PnzEbU+MZd37p0J0yC+9i48/uUpQQFtDyuXU
+eNnfAgMBAAGjUzBRMB0GA1UdDgQWBBSgVytk04RI+f2HiQz/Sm0af+fgnTAfBgNV
+HSMEGDAWgBSgVytk04RI+f2HiQz/Sm0af+fgnTAPBgNVHRMBAf8EBTADAQH/MA0G
+CSqGSIb3DQEBCwUAA4IBAQARUPIsVNfkNVAr3uhSMT01D3Bmq3oPttgW1/r7e6KS
+ECTUcNXKIaYCc/1T/8ZJe4A/I1/CWhbS5VZNKeQsJ5zc1hbzMiV+gLH/WybYRibm
+7FwAzIpnBHGw2xzHiFbdG198pdQErPRD/YAhzdPEbjwQ0AbBhAl0hWIxRlVHGVLT
+LcuWd5sVcghC9yB7czkPyRo/s604urnDP0UMfDjtjK1HrEKbRSoAkNLTJfqTT7Hs
+DDzj0ETYtvzr37PBdw2JT8i/+Z16Ho/Ur9e/fhUY4K5hqlws2XgLgPfDeLukUaGM
+vhrLV0f3GiFQc1QgnWI5L4UpLEjLmJ48H9ZevCTClZWd
+-----END CERTIFICATE-----
+`
+
+func init() {
+	resource.AddTestSweepers("sonarqube_saml_authentication", &resource.Sweeper{
+		Name: "sonarqube_saml_authentication",
+		F:    testSweepSonarqubeSamlAuthenticationSweeper,
+	})
+}
+
+func testSweepSonarqubeSamlAuthenticationSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeSamlAuthenticationConfig(rnd string, providerName string, loginURL string, certificate string) string {
+	return fmt.Sprintf(`
+        resource "sonarqube_saml_authentication" "%[1]s" {
+            provider_name   = "%[2]s"
+            provider_id     = "https://idp.example.com/metadata"
+            login_url       = "%[3]s"
+            certificate     = <<-EOT
+%[4]s
+EOT
+            login_attribute = "login"
+            name_attribute  = "name"
+            email_attribute = "email"
+        }`, rnd, providerName, loginURL, certificate)
+}
+
+func TestAccSonarqubeSamlAuthentication(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_saml_authentication." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeSamlAuthenticationConfig(rnd, "Test IdP", "https://idp.example.com/sso", "not a certificate"),
+				ExpectError: regexp.MustCompile("must be a PEM encoded certificate"),
+			},
+			{
+				Config:      testAccSonarqubeSamlAuthenticationConfig(rnd, "Test IdP", "idp.example.com/sso", testAccSonarqubeSamlAuthenticationCertificate),
+				ExpectError: regexp.MustCompile("expected \"login_url\" to have a host"),
+			},
+			{
+				Config: testAccSonarqubeSamlAuthenticationConfig(rnd, "Test IdP", "https://idp.example.com/sso", testAccSonarqubeSamlAuthenticationCertificate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+					resource.TestCheckResourceAttr(name, "provider_name", "Test IdP"),
+					resource.TestCheckResourceAttr(name, "login_url", "https://idp.example.com/sso"),
+					resource.TestCheckResourceAttr(name, "login_attribute", "login"),
+				),
+			},
+			{
+				Config: testAccSonarqubeSamlAuthenticationConfig(rnd, "Other IdP", "https://idp.example.com/sso", testAccSonarqubeSamlAuthenticationCertificate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "provider_name", "Other IdP"),
+				),
+			},
+		},
+	})
+}
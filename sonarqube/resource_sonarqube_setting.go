@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/exp/slices"
 )
 
 type Setting struct {
@@ -438,3 +440,150 @@ func removeComponentSettings(component string, newSettings []interface{}, apiPro
 	}
 	return nil
 }
+
+/* This content is used by the typed settings resources ('github_authentication', 'gitlab_authentication', 'saml_authentication') */
+
+// settingAttribute maps an attribute of a typed settings resource to a global setting key
+type settingAttribute struct {
+	attribute string
+	key       string
+	// Secured settings are write-only, the api only reports whether they are set
+	secured bool
+}
+
+func settingAttributeKeys(attributes []settingAttribute) []string {
+	keys := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		keys[i] = attribute.key
+	}
+	return keys
+}
+
+// setSettingAttributes writes the attributes to their global settings in the given order. Unless all is set, only changed
+// attributes are written. Empty strings and sets reset the setting to its default.
+func setSettingAttributes(d *schema.ResourceData, attributes []settingAttribute, all bool, m interface{}) error {
+	for _, attribute := range attributes {
+		if !all && !d.HasChange(attribute.attribute) {
+			continue
+		}
+
+		rawQuery := url.Values{
+			"key": []string{attribute.key},
+		}
+		switch value := d.Get(attribute.attribute).(type) {
+		case bool:
+			rawQuery.Add("value", strconv.FormatBool(value))
+		case string:
+			if value == "" {
+				if err := resetSettingAttributes([]settingAttribute{attribute}, m); err != nil {
+					return err
+				}
+				continue
+			}
+			rawQuery.Add("value", value)
+		case *schema.Set:
+			values := expandStringSet(value)
+			if len(values) == 0 {
+				if err := resetSettingAttributes([]settingAttribute{attribute}, m); err != nil {
+					return err
+				}
+				continue
+			}
+			sort.Strings(values)
+			rawQuery["values"] = values
+		}
+
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/set"
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"POST",
+			sonarQubeURL.String(),
+			http.StatusNoContent,
+			"setSettingAttributes",
+		)
+		if err != nil {
+			return fmt.Errorf("setSettingAttributes: Failed to set setting '%s': %+v", attribute.key, err)
+		}
+		resp.Body.Close()
+	}
+	return nil
+}
+
+// readSettingAttributes reads the global settings into their attributes. Secured settings keep the value of the state
+// unless they have been reset on the server.
+func readSettingAttributes(d *schema.ResourceData, attributes []settingAttribute, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/values"
+	sonarQubeURL.RawQuery = url.Values{
+		"keys": []string{strings.Join(settingAttributeKeys(attributes), ",")},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readSettingAttributes",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	settingReadResponse := GetSettings{}
+	err = json.NewDecoder(resp.Body).Decode(&settingReadResponse)
+	if err != nil {
+		return fmt.Errorf("readSettingAttributes: Failed to decode json into struct: %+v", err)
+	}
+
+	settings := make(map[string]Setting)
+	for _, setting := range settingReadResponse.Setting {
+		settings[setting.Key] = setting
+	}
+
+	for _, attribute := range attributes {
+		if attribute.secured {
+			if !slices.Contains(settingReadResponse.SetSecuredSettings, attribute.key) {
+				d.Set(attribute.attribute, "")
+			}
+			continue
+		}
+
+		setting := settings[attribute.key]
+		switch d.Get(attribute.attribute).(type) {
+		case bool:
+			d.Set(attribute.attribute, setting.Value == "true")
+		case string:
+			d.Set(attribute.attribute, setting.Value)
+		case *schema.Set:
+			d.Set(attribute.attribute, setting.Values)
+		}
+	}
+	return nil
+}
+
+// resetSettingAttributes resets the global settings of the attributes to their defaults
+func resetSettingAttributes(attributes []settingAttribute, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/reset"
+	sonarQubeURL.RawQuery = url.Values{
+		"keys": []string{strings.Join(settingAttributeKeys(attributes), ",")},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resetSettingAttributes",
+	)
+	if err != nil {
+		return fmt.Errorf("resetSettingAttributes: Failed to reset settings: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -19,6 +19,13 @@ type User struct {
 	Permissions []string `json:"permissions,omitempty"`
 	IsActive    bool     `json:"active,omitempty"`
 	IsLocal     bool     `json:"local,omitempty"`
+	ScmAccounts []string `json:"scmAccounts,omitempty"`
+	Groups      []string `json:"groups,omitempty"`
+	Managed     bool     `json:"managed,omitempty"`
+
+	ExternalIdentity   string `json:"externalIdentity,omitempty"`
+	ExternalProvider   string `json:"externalProvider,omitempty"`
+	LastConnectionDate string `json:"lastConnectionDate,omitempty"`
 }
 
 // GetUser for unmarshalling response body where users are retured
@@ -53,7 +60,6 @@ func resourceSonarqubeUser() *schema.Resource {
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"email": {
 				Type:     schema.TypeString,
@@ -70,18 +76,52 @@ func resourceSonarqubeUser() *schema.Resource {
 				Default:  true,
 				ForceNew: true,
 			},
+			"scm_accounts": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The SCM accounts of the user, used to attribute commits to the user",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"reactivate_if_deactivated": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Reactivate a deactivated user with the same login instead of failing on create",
+			},
+			"anonymize_on_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Anonymize the user on destroy. Overrides the anonymize_user_on_delete provider setting",
+			},
 		},
 	}
 }
 
 func resourceSonarqubeUserCreate(d *schema.ResourceData, m interface{}) error {
+	login := d.Get("login_name").(string)
+
+	deactivatedUser, err := findDeactivatedSonarqubeUser(login, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeUserCreate: %+v", err)
+	}
+	if deactivatedUser != nil && !d.Get("reactivate_if_deactivated").(bool) {
+		return fmt.Errorf("resourceSonarqubeUserCreate: A deactivated user with login '%s' already exists and reactivate_if_deactivated is false", login)
+	}
+
+	if usersV2ApiSupported(m) {
+		return resourceSonarqubeUserCreateV2(d, deactivatedUser != nil, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/create"
+	sonarQubeURLSubPath := sonarQubeURL.Path
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURLSubPath, "/") + "/api/users/create"
 
 	isLocal := d.Get("is_local").(bool)
 
 	rawQuery := url.Values{
-		"login": []string{d.Get("login_name").(string)},
+		"login": []string{login},
 		"name":  []string{d.Get("name").(string)},
 		"local": []string{strconv.FormatBool(isLocal)},
 	}
@@ -94,6 +134,10 @@ func resourceSonarqubeUserCreate(d *schema.ResourceData, m interface{}) error {
 		rawQuery.Add("email", email.(string))
 	}
 
+	for _, scmAccount := range expandStringSet(d.Get("scm_accounts").(*schema.Set)) {
+		rawQuery.Add("scmAccount", scmAccount)
+	}
+
 	sonarQubeURL.RawQuery = rawQuery.Encode()
 
 	resp, err := httpRequestHelper(
@@ -121,10 +165,45 @@ func resourceSonarqubeUserCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("resourceSonarqubeUserCreate: Create response didn't contain the user login")
 	}
 
+	// SonarQube reactivates a deactivated login on create, but deactivation cleared its email and SCM accounts.
+	// Restore them explicitly so the reactivated user matches the configuration.
+	if deactivatedUser != nil {
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURLSubPath, "/") + "/api/users/update"
+		updateQuery := url.Values{
+			"login": []string{d.Id()},
+			"name":  []string{d.Get("name").(string)},
+			"email": []string{d.Get("email").(string)},
+		}
+		scmAccounts := expandStringSet(d.Get("scm_accounts").(*schema.Set))
+		if len(scmAccounts) == 0 {
+			updateQuery.Add("scmAccount", "")
+		}
+		for _, scmAccount := range scmAccounts {
+			updateQuery.Add("scmAccount", scmAccount)
+		}
+		sonarQubeURL.RawQuery = updateQuery.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"POST",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"resourceSonarqubeUserCreate",
+		)
+		if err != nil {
+			return fmt.Errorf("error restoring reactivated Sonarqube user: %+v", err)
+		}
+		defer resp.Body.Close()
+	}
+
 	return resourceSonarqubeUserRead(d, m)
 }
 
 func resourceSonarqubeUserRead(d *schema.ResourceData, m interface{}) error {
+	if usersV2ApiSupported(m) {
+		return resourceSonarqubeUserReadV2(d, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/search"
 
@@ -160,6 +239,7 @@ func resourceSonarqubeUserRead(d *schema.ResourceData, m interface{}) error {
 			d.Set("name", value.Name)
 			d.Set("email", value.Email)
 			d.Set("is_local", value.IsLocal)
+			d.Set("scm_accounts", value.ScmAccounts)
 			return nil
 		}
 	}
@@ -170,13 +250,47 @@ func resourceSonarqubeUserRead(d *schema.ResourceData, m interface{}) error {
 func resourceSonarqubeUserUpdate(d *schema.ResourceData, m interface{}) error {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURLSubPath := sonarQubeURL.Path
-	// handle default updates (api/users/update)
-	if d.HasChange("email") {
+	if d.HasChanges("name", "email", "scm_accounts") && usersV2ApiSupported(m) {
+		user, err := findUserV2(d.Id(), true, m)
+		if err != nil {
+			return fmt.Errorf("error updating Sonarqube user: %+v", err)
+		}
+		if user == nil {
+			return fmt.Errorf("resourceSonarqubeUserUpdate: Failed to find user: %+v", d.Id())
+		}
+
+		changedAttributes := make([]string, 0)
+		for _, attribute := range []string{"name", "email", "scm_accounts"} {
+			if d.HasChange(attribute) {
+				changedAttributes = append(changedAttributes, attribute)
+			}
+		}
+		if err := updateSonarqubeUserV2(d, user.ID, changedAttributes, m); err != nil {
+			return fmt.Errorf("error updating Sonarqube user: %+v", err)
+		}
+	} else if d.HasChanges("name", "email", "scm_accounts") {
+		// handle default updates (api/users/update)
 		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURLSubPath, "/") + "/api/users/update"
-		sonarQubeURL.RawQuery = url.Values{
+		rawQuery := url.Values{
 			"login": []string{d.Id()},
-			"email": []string{d.Get("email").(string)},
-		}.Encode()
+		}
+		if d.HasChange("name") {
+			rawQuery.Add("name", d.Get("name").(string))
+		}
+		if d.HasChange("email") {
+			rawQuery.Add("email", d.Get("email").(string))
+		}
+		if d.HasChange("scm_accounts") {
+			scmAccounts := expandStringSet(d.Get("scm_accounts").(*schema.Set))
+			if len(scmAccounts) == 0 {
+				// An empty value removes all SCM accounts of the user
+				rawQuery.Add("scmAccount", "")
+			}
+			for _, scmAccount := range scmAccounts {
+				rawQuery.Add("scmAccount", scmAccount)
+			}
+		}
+		sonarQubeURL.RawQuery = rawQuery.Encode()
 
 		resp, err := httpRequestHelper(
 			m.(*ProviderConfiguration).httpClient,
@@ -192,6 +306,7 @@ func resourceSonarqubeUserUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// handle password updates (api/users/change_password)
+	// api/v2/users-management has no equivalent, so passwords are changed with api/users on every version
 	if d.HasChange("password") {
 		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURLSubPath, "/") + "/api/users/change_password"
 		sonarQubeURL.RawQuery = url.Values{
@@ -216,11 +331,15 @@ func resourceSonarqubeUserUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeUserDelete(d *schema.ResourceData, m interface{}) error {
+	if usersV2ApiSupported(m) {
+		return resourceSonarqubeUserDeleteV2(d, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/deactivate"
 	sonarQubeURL.RawQuery = url.Values{
 		"login":     []string{d.Id()},
-		"anonymize": []string{strconv.FormatBool(m.(*ProviderConfiguration).sonarQubeAnonymizeUsers)},
+		"anonymize": []string{strconv.FormatBool(anonymizeSonarqubeUserOnDelete(d, m))},
 	}.Encode()
 
 	resp, err := httpRequestHelper(
@@ -239,8 +358,197 @@ func resourceSonarqubeUserDelete(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeUserImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("reactivate_if_deactivated", true)
 	if err := resourceSonarqubeUserRead(d, m); err != nil {
 		return nil, err
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// anonymizeSonarqubeUserOnDelete returns anonymize_on_delete when it is configured and the provider setting otherwise
+func anonymizeSonarqubeUserOnDelete(d *schema.ResourceData, m interface{}) bool {
+	// The configuration is not available on destroy, an unset value is null in the state
+	if rawState := d.GetRawState(); !rawState.IsNull() {
+		if anonymize := rawState.GetAttr("anonymize_on_delete"); !anonymize.IsNull() {
+			return anonymize.True()
+		}
+	}
+	return m.(*ProviderConfiguration).sonarQubeAnonymizeUsers
+}
+
+// findDeactivatedSonarqubeUser returns the deactivated user with the given login, or nil if there is none
+func findDeactivatedSonarqubeUser(login string, m interface{}) (*User, error) {
+	if usersV2ApiSupported(m) {
+		user, err := findUserV2(login, false, m)
+		if err != nil || user == nil {
+			return nil, err
+		}
+		return &User{Login: user.Login, Name: user.Name, Email: user.Email, IsLocal: user.Local}, nil
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"ps":          []string{"500"},
+		"q":           []string{login},
+		"deactivated": []string{"true"},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"findDeactivatedSonarqubeUser",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	userResponse := GetUser{}
+	err = json.NewDecoder(resp.Body).Decode(&userResponse)
+	if err != nil {
+		return nil, fmt.Errorf("findDeactivatedSonarqubeUser: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, value := range userResponse.Users {
+		if value.Login == login {
+			return &value, nil
+		}
+	}
+	return nil, nil
+}
+
+func resourceSonarqubeUserCreateV2(d *schema.ResourceData, reactivated bool, m interface{}) error {
+	user := map[string]interface{}{
+		"login":       d.Get("login_name").(string),
+		"name":        d.Get("name").(string),
+		"local":       d.Get("is_local").(bool),
+		"scmAccounts": expandStringSet(d.Get("scm_accounts").(*schema.Set)),
+	}
+	if password, ok := d.GetOk("password"); ok {
+		user["password"] = password.(string)
+	}
+	if email, ok := d.GetOk("email"); ok {
+		user["email"] = email.(string)
+	}
+
+	resp, err := httpRequestJSONHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarqubeV2URL(m, "users-management/users", nil),
+		user,
+		contentTypeJSON,
+		http.StatusOK,
+		"resourceSonarqubeUserCreateV2",
+	)
+	if err != nil {
+		return fmt.Errorf("error creating Sonarqube user: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	userResponse := UserV2{}
+	err = json.NewDecoder(resp.Body).Decode(&userResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeUserCreateV2: Failed to decode json into struct: %+v", err)
+	}
+
+	if userResponse.Login != "" {
+		d.SetId(userResponse.Login)
+	} else {
+		return fmt.Errorf("resourceSonarqubeUserCreateV2: Create response didn't contain the user login")
+	}
+
+	// Restore the data cleared by the deactivation, as with api/users/create
+	if reactivated {
+		if err := updateSonarqubeUserV2(d, userResponse.ID, []string{"name", "email", "scm_accounts"}, m); err != nil {
+			return fmt.Errorf("error restoring reactivated Sonarqube user: %+v", err)
+		}
+	}
+
+	return resourceSonarqubeUserRead(d, m)
+}
+
+func resourceSonarqubeUserReadV2(d *schema.ResourceData, m interface{}) error {
+	user, err := findUserV2(d.Id(), true, m)
+	if err != nil {
+		return fmt.Errorf("error reading Sonarqube user: %+v", err)
+	}
+	if user == nil {
+		return fmt.Errorf("resourceSonarqubeUserRead: Failed to find user: %+v", d.Id())
+	}
+
+	d.SetId(user.Login)
+	d.Set("login_name", user.Login)
+	d.Set("name", user.Name)
+	d.Set("email", user.Email)
+	d.Set("is_local", user.Local)
+	d.Set("scm_accounts", user.ScmAccounts)
+	return nil
+}
+
+// updateSonarqubeUserV2 patches the given attributes of the user. Attributes that are not listed are left unchanged.
+func updateSonarqubeUserV2(d *schema.ResourceData, userID string, attributes []string, m interface{}) error {
+	patch := make(map[string]interface{})
+	for _, attribute := range attributes {
+		switch attribute {
+		case "name":
+			patch["name"] = d.Get("name").(string)
+		case "email":
+			// A null value removes the email in a merge patch
+			if email := d.Get("email").(string); email != "" {
+				patch["email"] = email
+			} else {
+				patch["email"] = nil
+			}
+		case "scm_accounts":
+			patch["scmAccounts"] = expandStringSet(d.Get("scm_accounts").(*schema.Set))
+		}
+	}
+
+	resp, err := httpRequestJSONHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"PATCH",
+		sonarqubeV2URL(m, "users-management/users/"+url.PathEscape(userID), nil),
+		patch,
+		contentTypeJSONMerge,
+		http.StatusOK,
+		"updateSonarqubeUserV2",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func resourceSonarqubeUserDeleteV2(d *schema.ResourceData, m interface{}) error {
+	user, err := findUserV2(d.Id(), true, m)
+	if err != nil {
+		return fmt.Errorf("error deleting (deactivating) Sonarqube user: %+v", err)
+	}
+	if user == nil {
+		// The user is already deactivated
+		return nil
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"DELETE",
+		sonarqubeV2URL(m, "users-management/users/"+url.PathEscape(user.ID), url.Values{
+			"anonymize": []string{strconv.FormatBool(anonymizeSonarqubeUserOnDelete(d, m))},
+		}),
+		http.StatusNoContent,
+		"resourceSonarqubeUserDeleteV2",
+	)
+	if err != nil {
+		return fmt.Errorf("error deleting (deactivating) Sonarqube user: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func init() {
@@ -99,3 +100,164 @@ func TestAccSonarqubeUserNotLocal(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeUserScmAccountsConfig(rnd string, login string, name string, scmAccounts string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name   = "%[2]s"
+			name         = "%[3]s"
+			password     = "secret-sauce37!"
+			scm_accounts = %[4]s
+		}`, rnd, login, name, scmAccounts)
+}
+
+func TestAccSonarqubeUserScmAccounts(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserScmAccountsConfig(rnd, "testAccSonarqubeUserScmAccounts", "Terraform Tset", `["scm-one", "scm-two"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "Terraform Tset"),
+					resource.TestCheckResourceAttr(name, "scm_accounts.#", "2"),
+					resource.TestCheckTypeSetElemAttr(name, "scm_accounts.*", "scm-one"),
+				),
+			},
+			{
+				Config: testAccSonarqubeUserScmAccountsConfig(rnd, "testAccSonarqubeUserScmAccounts", "Terraform Test", `["scm-two"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(name, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "Terraform Test"),
+					resource.TestCheckResourceAttr(name, "scm_accounts.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "scm_accounts.*", "scm-two"),
+				),
+			},
+			{
+				Config: testAccSonarqubeUserScmAccountsConfig(rnd, "testAccSonarqubeUserScmAccounts", "Terraform Test", `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "scm_accounts.#", "0"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func testAccSonarqubeUserReactivateConfig(rnd string, name string, email string, create bool) string {
+	if !create {
+		return "# the user is removed from the configuration"
+	}
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name          = "%[2]s"
+			name                = "%[2]s"
+			email               = "%[3]s"
+			password            = "secret-sauce37!"
+			anonymize_on_delete = false
+		}`, rnd, name, email)
+}
+
+func TestAccSonarqubeUserReactivate(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserReactivateConfig(rnd, "testAccSonarqubeUserReactivate", "terraform-test@sonarqube.com", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "email", "terraform-test@sonarqube.com"),
+				),
+			},
+			{
+				// Destroying the resource only deactivates the user
+				Config: testAccSonarqubeUserReactivateConfig(rnd, "testAccSonarqubeUserReactivate", "terraform-test@sonarqube.com", false),
+			},
+			{
+				Config: testAccSonarqubeUserReactivateConfig(rnd, "testAccSonarqubeUserReactivate", "terraform-test2@sonarqube.com", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "login_name", "testAccSonarqubeUserReactivate"),
+					resource.TestCheckResourceAttr(name, "email", "terraform-test2@sonarqube.com"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPreCheckUsersV2Api(t *testing.T) {
+	if !usersV2ApiSupported(testAccProvider.Meta()) {
+		t.Skipf("Skipping test of api/v2/users-management, which requires SonarQube 10.4")
+	}
+}
+
+func testAccSonarqubeUserV2Config(rnd string, login string, name string, email string, password string, scmAccounts string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name   = "%[2]s"
+			name         = "%[3]s"
+			email        = "%[4]s"
+			password     = "%[5]s"
+			scm_accounts = %[6]s
+		}`, rnd, login, name, email, password, scmAccounts)
+}
+
+func TestAccSonarqubeUserV2(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckUsersV2Api(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserV2Config(rnd, "testAccSonarqubeUserV2", "Terraform Test", "terraform-test@sonarqube.com", "secret-sauce37!", `["scm-one"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "testAccSonarqubeUserV2"),
+					resource.TestCheckResourceAttr(name, "name", "Terraform Test"),
+					resource.TestCheckResourceAttr(name, "email", "terraform-test@sonarqube.com"),
+					resource.TestCheckResourceAttr(name, "is_local", "true"),
+					resource.TestCheckResourceAttr(name, "scm_accounts.#", "1"),
+				),
+			},
+			{
+				// Name, email, SCM accounts and password are updated in place
+				Config: testAccSonarqubeUserV2Config(rnd, "testAccSonarqubeUserV2", "Terraform Test Updated", "", "secret-sauce38!", `["scm-two", "scm-three"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(name, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "Terraform Test Updated"),
+					resource.TestCheckResourceAttr(name, "email", ""),
+					resource.TestCheckResourceAttr(name, "scm_accounts.#", "2"),
+					resource.TestCheckTypeSetElemAttr(name, "scm_accounts.*", "scm-three"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
@@ -1,8 +1,10 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
 	"net/url"
 	"strings"
@@ -20,14 +22,15 @@ type GetTokens struct {
 
 // Token struct
 type Token struct {
-	Login          string       `json:"login,omitempty"`
-	Name           string       `json:"name,omitempty"`
-	Token          string       `json:"token,omitempty"`
-	ExpirationDate string       `json:"expirationDate,omitempty"`
-	Type           string       `json:"type,omitempty"`
-	CreatedAt      string       `json:"createdAt,omitempty"`
-	IsExpired      bool         `json:"isExpired,omitempty"`
-	Project        TokenProject `json:"project,omitempty"`
+	Login              string       `json:"login,omitempty"`
+	Name               string       `json:"name,omitempty"`
+	Token              string       `json:"token,omitempty"`
+	ExpirationDate     string       `json:"expirationDate,omitempty"`
+	Type               string       `json:"type,omitempty"`
+	CreatedAt          string       `json:"createdAt,omitempty"`
+	LastConnectionDate string       `json:"lastConnectionDate,omitempty"`
+	IsExpired          bool         `json:"isExpired,omitempty"`
+	Project            TokenProject `json:"project,omitempty"`
 }
 
 type TokenProject struct {
@@ -47,9 +50,14 @@ const (
 // Returns the resource represented by this file.
 func resourceSonarqubeUserToken() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSonarqubeUserTokenCreate,
-		Read:   resourceSonarqubeUserTokenRead,
-		Delete: resourceSonarqubeUserTokenDelete,
+		Create:        resourceSonarqubeUserTokenCreate,
+		Read:          resourceSonarqubeUserTokenRead,
+		Update:        resourceSonarqubeUserTokenUpdate,
+		Delete:        resourceSonarqubeUserTokenDelete,
+		CustomizeDiff: resourceSonarqubeUserTokenCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeUserTokenImport,
+		},
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
@@ -65,16 +73,44 @@ func resourceSonarqubeUserToken() *schema.Resource {
 				ForceNew: true,
 			},
 			"expiration_date": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"rotation_days"},
+			},
+			"rotation_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Description:      "Number of days the token is valid. The token is rotated when it enters the rotation window",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"rotate_before_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          7,
+				Description:      "Number of days before the expiration of the token in which it is rotated",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+			"rotation_required": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the token is inside its rotation window",
+			},
+			"token_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the token on the server. Rotated tokens get a timestamp suffix",
 			},
 			"token": {
 				Type:      schema.TypeString,
 				Computed:  true,
 				Sensitive: true,
 			},
+			"token_available": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the value of the token is known. It is false after an import, as the value is only returned when the token is generated",
+			},
 			"type": {
 				Type:             schema.TypeString,
 				Optional:         true,
@@ -92,66 +128,13 @@ func resourceSonarqubeUserToken() *schema.Resource {
 }
 
 func resourceSonarqubeUserTokenCreate(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_tokens/generate"
-
-	tokenType := TokenType(d.Get("type").(string))
-	rawQuery := url.Values{
-		"name": []string{d.Get("name").(string)},
-		"type": []string{string(tokenType)},
-	}
-
-	if tokenType == UserToken {
-		loginName := d.Get("login_name").(string)
-		if loginName != "" {
-			rawQuery.Add("login", loginName)
-		}
-	} else if tokenType == ProjectAnalysisToken {
-		projectKey := d.Get("project_key").(string)
-		if projectKey == "" {
-			return fmt.Errorf("resourceSonarqubeUserTokenCreate: 'project_key' must be configured when the token 'type' is %s", ProjectAnalysisToken)
-		}
-		rawQuery.Add("projectKey", projectKey)
-	}
-
-	if _, ok := d.GetOk("expiration_date"); ok {
-		rawQuery.Add("expirationDate", d.Get("expiration_date").(string))
-	}
-
-	sonarQubeURL.RawQuery = rawQuery.Encode()
-
-	resp, err := httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"POST",
-		sonarQubeURL.String(),
-		http.StatusOK,
-		"resourceSonarqubeUserTokenCreate",
-	)
-	if err != nil {
-		return fmt.Errorf("error creating Sonarqube user token: %+v", err)
-	}
-	defer resp.Body.Close()
-
-	// Decode response into struct
-	tokenResponse := Token{}
-	err = json.NewDecoder(resp.Body).Decode(&tokenResponse)
-	if err != nil {
-		return fmt.Errorf("resourceSonarqubeUserTokenCreate: Failed to decode json into struct: %+v", err)
-	}
-
-	if tokenResponse.Login != "" {
-		// the ID consists of the login_name and the token name (foo/bar)
-		d.SetId(fmt.Sprintf("%s/%s", d.Get("login_name").(string), d.Get("name").(string)))
-		// we set the token value here as the API wont return it later
-		if tokenResponse.Token != "" {
-			d.Set("token", tokenResponse.Token)
-		} else {
-			return fmt.Errorf("resourceSonarqubeUserTokenCreate: Create response didn't contain the token")
-		}
-	} else {
-		return fmt.Errorf("resourceSonarqubeUserTokenCreate: Create response didn't contain the user login")
+	tokenName := d.Get("name").(string)
+	if err := generateUserToken(d, tokenName, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeUserTokenCreate: %+v", err)
 	}
 
+	// the ID consists of the login_name and the token name (foo/bar)
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("login_name").(string), d.Get("name").(string)))
 	return resourceSonarqubeUserTokenRead(d, m)
 }
 
@@ -183,36 +166,64 @@ func resourceSonarqubeUserTokenRead(d *schema.ResourceData, m interface{}) error
 	getTokensResponse := GetTokens{}
 	err = json.NewDecoder(resp.Body).Decode(&getTokensResponse)
 	if err != nil {
-		return fmt.Errorf("resourceSonarqubeUserTokenCreate: Failed to decode json into struct: %+v", err)
+		return fmt.Errorf("resourceSonarqubeUserTokenRead: Failed to decode json into struct: %+v", err)
+	}
+
+	// Tokens created by older versions of the provider have no token_name, their server name is the configured name
+	tokenName := d.Get("token_name").(string)
+	if tokenName == "" {
+		tokenName = d.Get("name").(string)
 	}
 
 	// Loop over all user token to see if the current token exists.
 	if getTokensResponse.Tokens != nil {
 		for _, value := range getTokensResponse.Tokens {
-			if d.Get("name").(string) == value.Name {
+			if tokenName == value.Name {
 				d.SetId(fmt.Sprintf("%s/%s", d.Get("login_name").(string), d.Get("name").(string)))
 				d.Set("login_name", getTokensResponse.Login)
-				d.Set("name", value.Name)
+				d.Set("token_name", value.Name)
+				d.Set("token_available", d.Get("token").(string) != "")
+				if value.Type != "" {
+					d.Set("type", value.Type)
+				}
+				if value.Project.Key != "" {
+					d.Set("project_key", value.Project.Key)
+				}
+				rotationRequired := false
 				if value.ExpirationDate != "" {
 					dateReceived, errTimeParse := time.Parse("2006-01-02T15:04:05-0700", value.ExpirationDate)
 					if errTimeParse != nil {
-						return fmt.Errorf("resourceSonarqubeUserTokenCreate: Failed to parse ExpirationDate: %+v", err)
+						return fmt.Errorf("resourceSonarqubeUserTokenRead: Failed to parse ExpirationDate: %+v", errTimeParse)
 					}
 					d.Set("expiration_date", dateReceived.Format("2006-01-02"))
+
+					// The token is rotated once it is inside the rotation window before its expiration
+					if d.Get("rotation_days").(int) > 0 {
+						rotateAfter := dateReceived.AddDate(0, 0, -d.Get("rotate_before_days").(int))
+						rotationRequired = value.IsExpired || !time.Now().Before(rotateAfter)
+					}
 				}
+				d.Set("rotation_required", rotationRequired)
 				return nil
 			}
 		}
 	}
 
-	return fmt.Errorf("resourceSonarqubeUserTokenCreate: Failed to find user token: %+v", d.Id())
+	// The token was revoked, or removed by SonarQube after it expired
+	log.Printf("[WARN] User token '%s' not found, removing it from state", d.Id())
+	d.SetId("")
+	return nil
 }
 
 func resourceSonarqubeUserTokenDelete(d *schema.ResourceData, m interface{}) error {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_tokens/revoke"
+	tokenName := d.Get("token_name").(string)
+	if tokenName == "" {
+		tokenName = d.Get("name").(string)
+	}
 	rawQuery := url.Values{
-		"name": []string{d.Get("name").(string)},
+		"name": []string{tokenName},
 	}
 	login := d.Get("login_name").(string)
 	if login != "" {
@@ -235,3 +246,160 @@ func resourceSonarqubeUserTokenDelete(d *schema.ResourceData, m interface{}) err
 
 	return nil
 }
+
+func resourceSonarqubeUserTokenUpdate(d *schema.ResourceData, m interface{}) error {
+	oldRotationRequired, _ := d.GetChange("rotation_required")
+	rotationEnabled := d.HasChange("rotation_days") && d.Get("rotation_days").(int) > 0
+	if !oldRotationRequired.(bool) && !rotationEnabled {
+		return resourceSonarqubeUserTokenRead(d, m)
+	}
+
+	// Create the new token first, so the old one is only revoked once its replacement exists.
+	// token_name is planned as computed during a rotation, so the current server name is only available as the old value.
+	oldTokenNameValue, _ := d.GetChange("token_name")
+	oldTokenName := oldTokenNameValue.(string)
+	if oldTokenName == "" {
+		oldTokenName = d.Get("name").(string)
+	}
+	newTokenName := fmt.Sprintf("%s-%s", d.Get("name").(string), time.Now().UTC().Format("20060102150405"))
+	if err := generateUserToken(d, newTokenName, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeUserTokenUpdate: Failed to rotate token: %+v", err)
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_tokens/revoke"
+	rawQuery := url.Values{
+		"name": []string{oldTokenName},
+	}
+	if login := d.Get("login_name").(string); login != "" {
+		rawQuery.Add("login", login)
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeUserTokenUpdate",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeUserTokenUpdate: Failed to revoke rotated token '%s': %+v", oldTokenName, err)
+	}
+	defer resp.Body.Close()
+
+	return resourceSonarqubeUserTokenRead(d, m)
+}
+
+// resourceSonarqubeUserTokenImport imports a token by "login/name". The value of the token cannot be read back, so token stays
+// empty and token_available is false.
+func resourceSonarqubeUserTokenImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	idSlice := strings.SplitN(d.Id(), "/", 2)
+	if len(idSlice) != 2 || idSlice[1] == "" {
+		return nil, fmt.Errorf("resourceSonarqubeUserTokenImport: invalid id '%s', expected format 'login/name'", d.Id())
+	}
+
+	d.Set("login_name", idSlice[0])
+	d.Set("name", idSlice[1])
+	d.Set("token_name", idSlice[1])
+	d.Set("rotate_before_days", 7)
+	if err := resourceSonarqubeUserTokenRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeUserTokenImport: Failed to find user token '%s' of user '%s'", idSlice[1], idSlice[0])
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceSonarqubeUserTokenCustomizeDiff replaces a fixed expiration date and plans the rotation of tokens inside their rotation window
+func resourceSonarqubeUserTokenCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	rotationDays := d.Get("rotation_days").(int)
+	if rotationDays > 0 && d.Get("rotate_before_days").(int) >= rotationDays {
+		return fmt.Errorf("resourceSonarqubeUserTokenCustomizeDiff: rotate_before_days must be smaller than rotation_days")
+	}
+	if d.Id() == "" {
+		return nil
+	}
+
+	if rotationDays == 0 {
+		// Without rotation a different expiration date requires a new token
+		if d.HasChange("expiration_date") {
+			return d.ForceNew("expiration_date")
+		}
+		return nil
+	}
+
+	if d.Get("rotation_required").(bool) || d.HasChange("rotation_days") {
+		for _, key := range []string{"token", "token_name", "expiration_date"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return d.SetNew("rotation_required", false)
+	}
+	return nil
+}
+
+// generateUserToken generates a token with the given name on the server and stores its value and expiration
+func generateUserToken(d *schema.ResourceData, tokenName string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_tokens/generate"
+
+	tokenType := TokenType(d.Get("type").(string))
+	rawQuery := url.Values{
+		"name": []string{tokenName},
+		"type": []string{string(tokenType)},
+	}
+
+	if tokenType == UserToken {
+		loginName := d.Get("login_name").(string)
+		if loginName != "" {
+			rawQuery.Add("login", loginName)
+		}
+	} else if tokenType == ProjectAnalysisToken {
+		projectKey := d.Get("project_key").(string)
+		if projectKey == "" {
+			return fmt.Errorf("'project_key' must be configured when the token 'type' is %s", ProjectAnalysisToken)
+		}
+		rawQuery.Add("projectKey", projectKey)
+	}
+
+	if rotationDays := d.Get("rotation_days").(int); rotationDays > 0 {
+		rawQuery.Add("expirationDate", time.Now().AddDate(0, 0, rotationDays).Format("2006-01-02"))
+	} else if _, ok := d.GetOk("expiration_date"); ok {
+		rawQuery.Add("expirationDate", d.Get("expiration_date").(string))
+	}
+
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"generateUserToken",
+	)
+	if err != nil {
+		return fmt.Errorf("error creating Sonarqube user token: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	tokenResponse := Token{}
+	err = json.NewDecoder(resp.Body).Decode(&tokenResponse)
+	if err != nil {
+		return fmt.Errorf("Failed to decode json into struct: %+v", err)
+	}
+
+	if tokenResponse.Login == "" {
+		return fmt.Errorf("Create response didn't contain the user login")
+	}
+	// we set the token value here as the API wont return it later
+	if tokenResponse.Token == "" {
+		return fmt.Errorf("Create response didn't contain the token")
+	}
+	d.Set("token", tokenResponse.Token)
+	d.Set("token_name", tokenName)
+	return nil
+}
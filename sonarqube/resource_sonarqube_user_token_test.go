@@ -1,11 +1,18 @@
 package sonarqube
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func init() {
@@ -44,6 +51,40 @@ func TestAccSonarqubeUserTokenBasic(t *testing.T) {
 				Config: testAccSonarqubeUserTokenBasicConfig(rnd, "testAccSonarqubeUserToken"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeUserToken"),
+					resource.TestCheckResourceAttr(name, "token_available", "true"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateId:           "testAccSonarqubeUserToken/testAccSonarqubeUserToken",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token", "token_available"},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported token, got %d", len(states))
+					}
+					if value := states[0].Attributes["token_available"]; value != "false" {
+						return fmt.Errorf("expected token_available to be false after the import, got %s", value)
+					}
+					return nil
+				},
+			},
+			{
+				// A token revoked outside of terraform is generated again
+				PreConfig: func() {
+					d := schema.TestResourceDataRaw(t, resourceSonarqubeUserToken().Schema, map[string]interface{}{
+						"login_name": "testAccSonarqubeUserToken",
+						"name":       "testAccSonarqubeUserToken",
+					})
+					if err := resourceSonarqubeUserTokenDelete(d, testAccProvider.Meta()); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccSonarqubeUserTokenBasicConfig(rnd, "testAccSonarqubeUserToken"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "token_available", "true"),
+					resource.TestCheckResourceAttrSet(name, "token"),
 				),
 			},
 		},
@@ -179,3 +220,108 @@ func testAccSonarqubeUserTokenProjectAnalysisToken(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeUserTokenRotationConfig(rnd string, name string, rotationDays int) string {
+	return fmt.Sprintf(`
+        resource "sonarqube_user" "%[1]s" {
+            login_name = "%[2]s"
+            name       = "%[2]s"
+            password   = "secret-sauce37!"
+        }
+        resource "sonarqube_user_token" "%[1]s" {
+            login_name         = sonarqube_user.%[1]s.login_name
+            name               = "%[2]s"
+            rotation_days      = %[3]d
+            rotate_before_days = 7
+        }`, rnd, name, rotationDays)
+}
+
+func TestAccSonarqubeUserTokenRotation(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user_token." + rnd
+	rotatedTokenName := ""
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserTokenRotationConfig(rnd, "testAccSonarqubeUserTokenRotation", 30),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "testAccSonarqubeUserTokenRotation/testAccSonarqubeUserTokenRotation"),
+					resource.TestCheckResourceAttr(name, "token_name", "testAccSonarqubeUserTokenRotation"),
+					resource.TestCheckResourceAttr(name, "expiration_date", time.Now().AddDate(0, 0, 30).Format("2006-01-02")),
+					resource.TestCheckResourceAttr(name, "rotation_required", "false"),
+				),
+			},
+			{
+				// Changing the rotation period rotates the token in place
+				Config: testAccSonarqubeUserTokenRotationConfig(rnd, "testAccSonarqubeUserTokenRotation", 60),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "testAccSonarqubeUserTokenRotation/testAccSonarqubeUserTokenRotation"),
+					resource.TestMatchResourceAttr(name, "token_name", regexp.MustCompile(`^testAccSonarqubeUserTokenRotation-\d{14}$`)),
+					resource.TestCheckResourceAttr(name, "expiration_date", time.Now().AddDate(0, 0, 60).Format("2006-01-02")),
+					testAccCheckSonarqubeUserTokenOnlyCurrent(name),
+					func(s *terraform.State) error {
+						rotatedTokenName = s.RootModule().Resources[name].Primary.Attributes["token_name"]
+						return nil
+					},
+				),
+			},
+			{
+				// A second rotation revokes the token created by the first one
+				PreConfig: func() { time.Sleep(time.Second) },
+				Config:    testAccSonarqubeUserTokenRotationConfig(rnd, "testAccSonarqubeUserTokenRotation", 90),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(name, "token_name", regexp.MustCompile(`^testAccSonarqubeUserTokenRotation-\d{14}$`)),
+					resource.TestCheckResourceAttr(name, "expiration_date", time.Now().AddDate(0, 0, 90).Format("2006-01-02")),
+					testAccCheckSonarqubeUserTokenOnlyCurrent(name),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources[name].Primary.Attributes["token_name"] == rotatedTokenName {
+							return fmt.Errorf("token was not rotated a second time")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSonarqubeUserTokenOnlyCurrent checks that the token in the state is the only token of the user with its name prefix,
+// so every rotated token has been revoked
+func testAccCheckSonarqubeUserTokenOnlyCurrent(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		attributes := s.RootModule().Resources[name].Primary.Attributes
+		m := testAccProvider.Meta()
+
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_tokens/search"
+		sonarQubeURL.RawQuery = url.Values{
+			"login": []string{attributes["login_name"]},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"testAccCheckSonarqubeUserTokenOnlyCurrent",
+		)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		getTokensResponse := GetTokens{}
+		if err := json.NewDecoder(resp.Body).Decode(&getTokensResponse); err != nil {
+			return err
+		}
+		for _, token := range getTokensResponse.Tokens {
+			if strings.HasPrefix(token.Name, attributes["name"]) && token.Name != attributes["token_name"] {
+				return fmt.Errorf("token '%s' was not revoked after the rotation to '%s'", token.Name, attributes["token_name"])
+			}
+		}
+		return nil
+	}
+}
@@ -3,6 +3,9 @@ package sonarqube
 import (
 	"reflect"
 	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // Checks if two string slices are equal, optionally ignoring ordering
@@ -18,3 +21,22 @@ func stringSlicesEqual(a, b []string, ignoreOrder bool) bool {
 
 	return reflect.DeepEqual(a, b)
 }
+
+// Converts a set of strings from the schema into a string slice
+func expandStringSet(input *schema.Set) []string {
+	expanded := make([]string, 0)
+	for _, value := range input.List() {
+		expanded = append(expanded, value.(string))
+	}
+	return expanded
+}
+
+// Checks if a string slice contains a value, ignoring case
+func containsStringFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}